@@ -2,15 +2,29 @@
 package bamboohr
 
 import (
+	"compress/gzip"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
+	"net/url"
+	"path"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
 	"time"
 
 	"gopkg.in/errgo.v2/errors"
 )
 
+// APIHost is the host Bamboo HR serves its API from.  Bamboo doesn't publish separate regional hosts, so this is
+// used for all companies; use SetBaseURL to go through a proxy or gateway instead.
+const APIHost = "api.bamboohr.com"
+
 // Client represents connectivity to the bamboo hr API
 type Client struct {
 	// Base URL for Bamboo HR API which is set to v1 using the provided company domain if initiated with `bamboohr.New()`
@@ -21,6 +35,49 @@ type Client struct {
 
 	// Base64 Encoded string based on the APIKey, used for Basic Authorization
 	Auth string
+
+	// Fields to request from GetEmployee when none are provided, replacing the built-in list of all fields if set.
+	DefaultEmployeeFields EmployeeFields
+
+	// Also request the AddressFields from GetEmployee when no fields are provided.
+	IncludeAddressFields bool
+
+	// Largest response body that will be read, in bytes, after decompression.  Defaults to 100MB if not set.
+	MaxResponseSize int64
+
+	// Largest photo UploadEmployeePhotoFromURL will download, in bytes.  Defaults to 5MB if not set.
+	MaxPhotoDownloadSize int64
+
+	// Time zones for GetEmployeeTimeZone keyed by location, e.g. "London (LDN)": "Europe/London".  Locations
+	// are matched ignoring case and extra whitespace.
+	LocationTimeZones map[string]string
+
+	// Alias of a field holding the employee's time zone, for companies that store it, used by GetEmployeeTimeZone
+	// in preference to their location.
+	TimeZoneField string
+
+	// Reject responses containing fields the destination type doesn't have, rather than silently dropping them.
+	// This is meant for tests and development, to catch Bamboo adding or renaming fields, and will break when the
+	// API changes.  This covers the fields of each Employee too.
+	StrictJSON bool
+
+	// How long GetFields and GetTables reuse the metadata they last fetched, saving a request each time custom fields
+	// are resolved or validated.  Metadata isn't cached if not set; use RefreshMetaCache to pick up changes sooner.
+	MetaCacheTTL time.Duration
+
+	// directory holds the last directory response, for conditional requests and GetEmployeePhotos
+	directory directoryCache
+
+	// meta holds the field and table metadata when MetaCacheTTL is set
+	meta metaCache
+}
+
+// directoryCache holds the last employee directory along with the validators BambooHR returned for it.
+type directoryCache struct {
+	mu           sync.Mutex
+	etag         string
+	lastModified string
+	employees    []Employee
 }
 
 // New is a helper function that returns a new instance of the bamboo hr client given a company domain and api key.
@@ -38,35 +95,261 @@ func New(apikey string, companyDomain string, client *http.Client) (*Client, err
 		}
 	}
 	c := &Client{
-		BaseURL:    fmt.Sprintf("https://api.bamboohr.com/api/gateway.php/%s/v1", companyDomain),
+		BaseURL:    fmt.Sprintf("https://%s/api/gateway.php/%s/v1", APIHost, companyDomain),
 		HTTPClient: client,
 		Auth:       fmt.Sprintf("Basic %s", base64.StdEncoding.EncodeToString([]byte(apikey+":x"))),
 	}
 	return c, nil
 }
 
+// LoginURL returns the address of the company's Bamboo HR site, which takes people to log in or to the company's
+// single sign-on provider.  Bamboo doesn't provide an API to generate a login link for a specific employee.
+func LoginURL(companyDomain string) string {
+	return fmt.Sprintf("https://%s.bamboohr.com/", companyDomain)
+}
+
+// SetBaseURL changes the URL requests are made to, e.g. "https://proxy.example.com/api/gateway.php/acmecorp/v1".
+// An error is returned if the URL isn't an absolute http or https URL.
+func (c *Client) SetBaseURL(baseURL string) error {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid base URL: %q", baseURL)
+	}
+	c.BaseURL = strings.TrimSuffix(u.String(), "/")
+	return nil
+}
+
+// WithTenant returns a new Client for a different company domain and api key, useful when working with many
+// Bamboo HR accounts.  The new Client shares this Client's HTTPClient, and therefore its connection pool, and copies
+// the rest of its configuration, such as DefaultEmployeeFields, MaxResponseSize and StrictJSON.  If the BaseURL was
+// changed, e.g. to go through a proxy, the new Client uses the same URL with the company domain replaced, as long as
+// it ends in "/gateway.php/{companyDomain}/v1"; otherwise the new Client uses the default BaseURL.  Only the
+// configuration is copied, e.g. each Client keeps its own directory and metadata caches.
+func (c *Client) WithTenant(companyDomain string, apikey string) (*Client, error) {
+	t, err := New(apikey, companyDomain, c.HTTPClient)
+	if err != nil {
+		return nil, err
+	}
+	if m := tenantBaseURL.FindStringSubmatch(c.BaseURL); m != nil {
+		t.BaseURL = m[1] + url.PathEscape(companyDomain) + m[2]
+	}
+	t.DefaultEmployeeFields = append(EmployeeFields(nil), c.DefaultEmployeeFields...)
+	t.IncludeAddressFields = c.IncludeAddressFields
+	t.MaxResponseSize = c.MaxResponseSize
+	t.MaxPhotoDownloadSize = c.MaxPhotoDownloadSize
+	t.StrictJSON = c.StrictJSON
+	t.TimeZoneField = c.TimeZoneField
+	t.MetaCacheTTL = c.MetaCacheTTL
+	if c.LocationTimeZones != nil {
+		t.LocationTimeZones = make(map[string]string, len(c.LocationTimeZones))
+		for l, tz := range c.LocationTimeZones {
+			t.LocationTimeZones[l] = tz
+		}
+	}
+	return t, nil
+}
+
+// tenantBaseURL matches a BaseURL, capturing the parts either side of the company domain
+var tenantBaseURL = regexp.MustCompile(`^(.*/gateway\.php/)[^/]+(/v1)$`)
+
+// Close releases the idle connections held by the Client's HTTPClient, for use when shutting down.
+// The Client doesn't start any background work of its own, so this is all there is to release.  It is safe to call
+// more than once, and the Client can still be used afterwards, opening new connections as needed.  Clients created
+// by WithTenant share an HTTPClient, so closing one closes the idle connections of all of them.
+func (c *Client) Close() error {
+	if c.HTTPClient != nil {
+		c.HTTPClient.CloseIdleConnections()
+	}
+	return nil
+}
+
 // makeRequest provides a single function to add common items to the request.
 func (c *Client) makeRequest(req *http.Request, v interface{}) error {
-	// Set standard headers
-	req.Header.Set("Authorization", c.Auth)
-	req.Header.Set("Accept", "application/json")
-	// Make the request
-	res, err := c.HTTPClient.Do(req)
+	res, err := c.doRequest(req)
 	if err != nil {
 		return err
 	}
 	defer res.Body.Close()
-	// Check we have a desired status code, e.g. between 200 and 400
-	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusBadRequest {
-		return fmt.Errorf("error from bamboo, status code: %d", res.StatusCode)
-	}
 	// If we're just getting a created (201), then it's ok. We might want to return a struct at some point
 	if res.StatusCode == http.StatusCreated {
 		return nil
 	}
+	// Some endpoints, such as updates, return an empty body so there is nothing to decode
+	if v == nil {
+		return nil
+	}
 	// Decode the body to the supplied interface
-	if err = json.NewDecoder(res.Body).Decode(&v); err != nil {
+	return c.decode(res.Body, v)
+}
+
+// decode decodes a response body into v, rejecting unknown fields if StrictJSON is set
+func (c *Client) decode(r io.Reader, v interface{}) error {
+	if !c.StrictJSON {
+		return json.NewDecoder(r).Decode(v)
+	}
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
 		return err
 	}
-	return nil
+	if err := json.Unmarshal(b, v); err != nil {
+		return err
+	}
+	var raw interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	return checkUnknownFields(raw, reflect.TypeOf(v), "")
+}
+
+// WriteResult describes Bamboo's response to a request that created or changed something
+type WriteResult struct {
+	// ID of the created item, taken from the Location header
+	ID         string
+	StatusCode int
+	Location   string
+}
+
+// makeWriteRequest makes a request that creates or changes something, returning details of the response.
+func (c *Client) makeWriteRequest(req *http.Request) (WriteResult, error) {
+	res, err := c.doRequest(req)
+	if err != nil {
+		return WriteResult{}, err
+	}
+	defer res.Body.Close()
+	wr := WriteResult{
+		StatusCode: res.StatusCode,
+		Location:   res.Header.Get("Location"),
+	}
+	if wr.Location != "" {
+		wr.ID = path.Base(wr.Location)
+	}
+	return wr, nil
+}
+
+// maxConcurrentRequests limits how many requests the bulk helpers will have in flight at once
+const maxConcurrentRequests = 5
+
+// forEachConcurrently calls fn for each of the IDs with bounded parallelism, returning the errors keyed by ID.
+// Once the context is done no more calls are started, and the remaining IDs fail with the context's error.
+func forEachConcurrently(ctx context.Context, ids []string, fn func(id string) error) map[string]error {
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		errs = map[string]error{}
+		sem  = make(chan struct{}, maxConcurrentRequests)
+	)
+	for _, id := range ids {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			mu.Lock()
+			errs[id] = ctx.Err()
+			mu.Unlock()
+			continue
+		}
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := fn(id); err != nil {
+				mu.Lock()
+				errs[id] = err
+				mu.Unlock()
+			}
+		}(id)
+	}
+	wg.Wait()
+	return errs
+}
+
+// doRequest sets the standard headers, makes the request and checks the status code.
+// The caller is responsible for closing the body of the returned response.
+func (c *Client) doRequest(req *http.Request) (*http.Response, error) {
+	// Set standard headers, leaving Accept alone if the caller wants something other than JSON
+	req.Header.Set("Authorization", c.Auth)
+	if req.Header.Get("Accept") == "" {
+		req.Header.Set("Accept", "application/json")
+	}
+	// Ask for compressed responses explicitly, which means the transport leaves decompression to us
+	req.Header.Set("Accept-Encoding", "gzip")
+	// Make the request
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Header.Get("Content-Encoding") == "gzip" {
+		res.Body = &gzipBody{body: res.Body}
+		res.Header.Del("Content-Encoding")
+		res.Header.Del("Content-Length")
+		res.ContentLength = -1
+		res.Uncompressed = true
+	}
+	// Check we have a desired status code, e.g. between 200 and 400
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusBadRequest {
+		defer res.Body.Close()
+		return nil, newAPIError(res)
+	}
+	limit := c.MaxResponseSize
+	if limit <= 0 {
+		limit = defaultMaxResponseSize
+	}
+	res.Body = &limitedBody{body: res.Body, remaining: limit}
+	return res, nil
+}
+
+// defaultMaxResponseSize is used when the Client doesn't set MaxResponseSize
+const defaultMaxResponseSize = 100 << 20
+
+// limitedBody returns ErrResponseTooLarge once more than the given number of bytes have been read from a response body.
+type limitedBody struct {
+	body      io.ReadCloser
+	remaining int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining < 0 {
+		return 0, ErrResponseTooLarge
+	}
+	// Read one byte past the limit so we can tell a body of exactly the limit from one that's too large.  This is
+	// written so it can't overflow when the limit is math.MaxInt64.
+	if b.remaining < int64(len(p))-1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.body.Read(p)
+	b.remaining -= int64(n)
+	if b.remaining < 0 {
+		return n - 1, ErrResponseTooLarge
+	}
+	return n, err
+}
+
+func (b *limitedBody) Close() error {
+	return b.body.Close()
+}
+
+// gzipBody decompresses a response body as it's read, so bodies that are never read don't need to be valid gzip.
+type gzipBody struct {
+	body io.ReadCloser
+	zr   *gzip.Reader
+}
+
+func (b *gzipBody) Read(p []byte) (int, error) {
+	if b.zr == nil {
+		zr, err := gzip.NewReader(b.body)
+		if err != nil {
+			return 0, err
+		}
+		b.zr = zr
+	}
+	return b.zr.Read(p)
+}
+
+func (b *gzipBody) Close() error {
+	if b.zr != nil {
+		b.zr.Close()
+	}
+	return b.body.Close()
 }
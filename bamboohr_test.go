@@ -0,0 +1,282 @@
+package bamboohr
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a Client making its requests to a test server using the given handler
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	c, err := New("key", "company", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.BaseURL = server.URL
+	return c
+}
+
+// fixture returns the contents of a file in testdata
+func fixture(t *testing.T, name string) []byte {
+	t.Helper()
+	b, err := ioutil.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// serveFixture returns a handler responding to every request with a file from testdata
+func serveFixture(t *testing.T, name string) http.HandlerFunc {
+	b := fixture(t, name)
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Write(b)
+	}
+}
+
+func TestStrictJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		strict bool
+		fails  bool
+		call   func(c *Client) error
+	}{
+		{
+			name: "lenient ignores unknown fields",
+			body: `[{"id":1,"name":"First Name","type":"text","alias":"firstName","brandNewField":"x"}]`,
+			call: getFields,
+		},
+		{
+			name:   "strict rejects unknown fields",
+			body:   `[{"id":1,"name":"First Name","type":"text","alias":"firstName","brandNewField":"x"}]`,
+			strict: true,
+			fails:  true,
+			call:   getFields,
+		},
+		{
+			name:   "strict rejects unknown directory employee fields",
+			body:   `{"fields":[],"employees":[{"id":"1","brandNewField":"x"}]}`,
+			strict: true,
+			fails:  true,
+			call:   getDirectory,
+		},
+		{
+			name:   "strict accepts known directory fields",
+			body:   `{"fields":[{"id":"displayName","type":"text","name":"Display name"}],"employees":[{"id":"1","displayName":"Jo Bloggs","canUploadPhoto":"1"}]}`,
+			strict: true,
+			call:   getDirectory,
+		},
+		{
+			name: "lenient ignores unknown employee fields",
+			body: `{"id":"1","brandNewField":"x"}`,
+			call: getEmployee,
+		},
+		{
+			name:   "strict rejects unknown employee fields",
+			body:   `{"id":"1","brandNewField":"x"}`,
+			strict: true,
+			fails:  true,
+			call:   getEmployee,
+		},
+		{
+			name:   "strict accepts report title",
+			body:   `{"title":"Report","fields":[],"employees":[{"id":"1","status":"Inactive"}]}`,
+			strict: true,
+			call:   getInactiveDirectory,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(tt.body))
+			})
+			c.StrictJSON = tt.strict
+			err := tt.call(c)
+			if tt.fails && err == nil {
+				t.Error("expected an error")
+			}
+			if !tt.fails && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func getFields(c *Client) error {
+	_, err := c.GetFields(context.Background())
+	return err
+}
+
+func getDirectory(c *Client) error {
+	_, err := c.GetEmployeeDirectory(context.Background())
+	return err
+}
+
+func getInactiveDirectory(c *Client) error {
+	_, err := c.GetEmployeeDirectoryWithOptions(context.Background(), DirectoryOptions{IncludeInactive: true})
+	return err
+}
+
+func getEmployee(c *Client) error {
+	_, err := c.GetEmployee(context.Background(), "1", FirstName)
+	return err
+}
+
+func TestWithTenant(t *testing.T) {
+	c, err := New("key", "acme", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetBaseURL("https://proxy.example.com/bamboo/api/gateway.php/acme/v1"); err != nil {
+		t.Fatal(err)
+	}
+	c.DefaultEmployeeFields = EmployeeFields{FirstName, LastName}
+	c.IncludeAddressFields = true
+	c.MaxResponseSize = 1 << 10
+	c.MaxPhotoDownloadSize = 1 << 11
+	c.StrictJSON = true
+	c.LocationTimeZones = map[string]string{"London": "Europe/London"}
+	c.TimeZoneField = "customTimeZone"
+	c.MetaCacheTTL = time.Minute
+
+	tenant, err := c.WithTenant("globex", "other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "https://proxy.example.com/bamboo/api/gateway.php/globex/v1"; tenant.BaseURL != want {
+		t.Errorf("BaseURL = %q, want %q", tenant.BaseURL, want)
+	}
+	if tenant.HTTPClient != c.HTTPClient {
+		t.Error("HTTPClient not shared")
+	}
+	if tenant.Auth == c.Auth {
+		t.Error("Auth not replaced")
+	}
+	if !reflect.DeepEqual(tenant.DefaultEmployeeFields, c.DefaultEmployeeFields) ||
+		tenant.IncludeAddressFields != c.IncludeAddressFields ||
+		tenant.MaxResponseSize != c.MaxResponseSize ||
+		tenant.MaxPhotoDownloadSize != c.MaxPhotoDownloadSize ||
+		tenant.StrictJSON != c.StrictJSON ||
+		!reflect.DeepEqual(tenant.LocationTimeZones, c.LocationTimeZones) ||
+		tenant.TimeZoneField != c.TimeZoneField ||
+		tenant.MetaCacheTTL != c.MetaCacheTTL {
+		t.Error("configuration not copied")
+	}
+	c.LocationTimeZones["Paris"] = "Europe/Paris"
+	if _, ok := tenant.LocationTimeZones["Paris"]; ok {
+		t.Error("LocationTimeZones shared rather than copied")
+	}
+
+	if err := c.SetBaseURL("https://proxy.example.com/bamboo"); err != nil {
+		t.Fatal(err)
+	}
+	tenant, err = c.WithTenant("globex", "other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "https://api.bamboohr.com/api/gateway.php/globex/v1"; tenant.BaseURL != want {
+		t.Errorf("BaseURL = %q, want the default %q", tenant.BaseURL, want)
+	}
+}
+
+// gzipped returns the body compressed with gzip
+func gzipped(t *testing.T, body string) []byte {
+	t.Helper()
+	var b bytes.Buffer
+	zw := gzip.NewWriter(&b)
+	if _, err := zw.Write([]byte(body)); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes()
+}
+
+func TestGzipResponse(t *testing.T) {
+	const body = `{"fields":[],"employees":[{"id":"1","displayName":"Jo Bloggs"},{"id":"2","displayName":"Alex Jones"}]}`
+	var acceptEncoding string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		acceptEncoding = r.Header.Get("Accept-Encoding")
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(gzipped(t, body))
+	})
+	employees, err := c.GetEmployeeDirectory(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if acceptEncoding != "gzip" {
+		t.Errorf("Accept-Encoding = %q, want gzip", acceptEncoding)
+	}
+	if len(employees) != 2 || employees[1].DisplayName != "Alex Jones" {
+		t.Errorf("got %+v", employees)
+	}
+}
+
+func TestGzipErrorResponse(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write(gzipped(t, "invalid field"))
+	})
+	_, err := c.GetEmployeeDirectory(context.Background())
+	if e, ok := err.(*APIError); !ok || e.Message != "invalid field" {
+		t.Errorf("err = %v, want an APIError with the decompressed message", err)
+	}
+}
+
+func TestMaxResponseSize(t *testing.T) {
+	const body = `{"fields":[],"employees":[{"id":"1","displayName":"Jo Bloggs"}]}`
+	tests := []struct {
+		name  string
+		limit int64
+		gzip  bool
+		fails bool
+	}{
+		{name: "default limit", limit: 0},
+		{name: "no limit", limit: math.MaxInt64},
+		{name: "no limit gzip", limit: math.MaxInt64, gzip: true},
+		{name: "exactly the limit", limit: int64(len(body))},
+		{name: "over the limit", limit: int64(len(body)) - 1, fails: true},
+		{name: "gzip limited after decompression", limit: int64(len(body)) - 1, gzip: true, fails: true},
+		{name: "gzip within the limit", limit: int64(len(body)), gzip: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if tt.gzip {
+					w.Header().Set("Content-Encoding", "gzip")
+					w.Write(gzipped(t, body))
+					return
+				}
+				w.Write([]byte(body))
+			})
+			c.MaxResponseSize = tt.limit
+			employees, err := c.GetEmployeeDirectory(context.Background())
+			if tt.fails {
+				if err != ErrResponseTooLarge {
+					t.Errorf("err = %v, want ErrResponseTooLarge", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(employees) != 1 || employees[0].DisplayName != "Jo Bloggs" {
+				t.Errorf("got %+v", employees)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package bamboohr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// DirectoryIndex indexes a list of employees, e.g. from GetEmployeeDirectory, to look up employees and supervisors.
+type DirectoryIndex struct {
+	byID   map[string]Employee
+	byName map[string][]string
+	// order of the employees as given, for stable results
+	ids []string
+}
+
+// NewDirectoryIndex returns a DirectoryIndex of the given employees
+func NewDirectoryIndex(directory []Employee) *DirectoryIndex {
+	ix := &DirectoryIndex{
+		byID:   make(map[string]Employee, len(directory)),
+		byName: map[string][]string{},
+	}
+	for _, e := range directory {
+		if _, ok := ix.byID[e.ID]; ok {
+			continue
+		}
+		ix.byID[e.ID] = e
+		ix.ids = append(ix.ids, e.ID)
+		seen := map[string]bool{}
+		for _, name := range []string{
+			e.DisplayName,
+			e.FirstName + " " + e.LastName,
+			e.PreferredName + " " + e.LastName,
+			e.LastName + ", " + e.FirstName,
+		} {
+			key := normalizeName(name)
+			if key == "" || key == "," || seen[key] {
+				continue
+			}
+			seen[key] = true
+			ix.byName[key] = append(ix.byName[key], e.ID)
+		}
+	}
+	return ix
+}
+
+// Employee returns the employee with the given ID
+func (ix *DirectoryIndex) Employee(id string) (Employee, bool) {
+	e, ok := ix.byID[id]
+	return e, ok
+}
+
+// SupervisorID returns the ID of the employee's supervisor.  SupervisorEID is used if it matches an employee,
+// otherwise SupervisorName is matched against employee IDs and then names, as records can hold either.
+// False is returned if the employee has no supervisor or it can't be resolved to exactly one employee.
+func (ix *DirectoryIndex) SupervisorID(e Employee) (string, bool) {
+	if id := strings.TrimSpace(e.SupervisorEID); id != "" {
+		if _, ok := ix.byID[id]; ok {
+			return id, true
+		}
+	}
+	name := strings.TrimSpace(e.SupervisorName)
+	if name == "" {
+		return "", false
+	}
+	if _, ok := ix.byID[name]; ok {
+		return name, true
+	}
+	if ids := ix.byName[normalizeName(name)]; len(ids) == 1 {
+		return ids[0], true
+	}
+	return "", false
+}
+
+// DirectReports returns the employees whose supervisor is the employee with the given ID, in directory order
+func (ix *DirectoryIndex) DirectReports(id string) []Employee {
+	var reports []Employee
+	for _, rid := range ix.ids {
+		e := ix.byID[rid]
+		if sid, ok := ix.SupervisorID(e); ok && sid == id && rid != id {
+			reports = append(reports, e)
+		}
+	}
+	return reports
+}
+
+// ReportingChain returns the supervisors of the employee with the given ID, starting with their own supervisor
+// and ending with the top of the chain.  An error is returned if the chain loops back on itself.
+func (ix *DirectoryIndex) ReportingChain(id string) ([]Employee, error) {
+	e, ok := ix.byID[id]
+	if !ok {
+		return nil, ErrEmployeeNotFound
+	}
+	var chain []Employee
+	seen := map[string]bool{id: true}
+	for {
+		sid, ok := ix.SupervisorID(e)
+		if !ok {
+			return chain, nil
+		}
+		if seen[sid] {
+			return nil, fmt.Errorf("reporting chain for employee %s loops at employee %s", id, sid)
+		}
+		seen[sid] = true
+		e = ix.byID[sid]
+		chain = append(chain, e)
+	}
+}
+
+// GetManagerEmail returns the work email of an employee's supervisor, which is empty if the supervisor doesn't have
+// one.  The employee's supervisor is requested from their record, since the directory may not include it, and then
+// looked up in the directory.  ErrNoManager is returned if the employee doesn't have a supervisor, and
+// ErrEmployeeNotFound if the employee doesn't exist or their supervisor can't be found in the directory.
+func (c *Client) GetManagerEmail(ctx context.Context, employeeID string) (string, error) {
+	e, err := c.GetEmployee(ctx, employeeID, SupervisorEID, Supervisor)
+	if isStatus(err, http.StatusNotFound) {
+		return "", ErrEmployeeNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(e.SupervisorEID) == "" && strings.TrimSpace(e.SupervisorName) == "" {
+		return "", ErrNoManager
+	}
+	directory, err := c.GetEmployeeDirectory(ctx)
+	if err != nil {
+		return "", err
+	}
+	ix := NewDirectoryIndex(directory)
+	sid, ok := ix.SupervisorID(e)
+	if !ok {
+		return "", ErrEmployeeNotFound
+	}
+	manager, _ := ix.Employee(sid)
+	return manager.WorkEmail, nil
+}
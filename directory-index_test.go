@@ -0,0 +1,115 @@
+package bamboohr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// mixedSupervisors is a directory where supervisors are recorded in each of the ways Bamboo holds them
+var mixedSupervisors = []Employee{
+	{ID: "1", DisplayName: "Ada Chief", FirstName: "Ada", LastName: "Chief"},
+	{ID: "2", DisplayName: "Bo Director", FirstName: "Bo", LastName: "Director", SupervisorEID: "1"},
+	{ID: "3", DisplayName: "Cy Manager", FirstName: "Cy", LastName: "Manager", SupervisorName: "2"},
+	{ID: "4", DisplayName: "Di Lead", FirstName: "Di", LastName: "Lead", SupervisorName: "Manager, Cy"},
+	{ID: "5", DisplayName: "Ed Engineer", FirstName: "Ed", LastName: "Engineer", SupervisorName: " di  LEAD "},
+	{ID: "6", DisplayName: "Fi Engineer", FirstName: "Fi", LastName: "Engineer", SupervisorEID: "99", SupervisorName: "Di Lead"},
+	{ID: "7", DisplayName: "Gu Contractor", FirstName: "Gu", LastName: "Contractor", SupervisorName: "Someone Else"},
+}
+
+func TestSupervisorID(t *testing.T) {
+	ix := NewDirectoryIndex(mixedSupervisors)
+	tests := []struct {
+		id     string
+		want   string
+		wantOK bool
+	}{
+		{id: "1"},
+		{id: "2", want: "1", wantOK: true},
+		{id: "3", want: "2", wantOK: true},
+		{id: "4", want: "3", wantOK: true},
+		{id: "5", want: "4", wantOK: true},
+		{id: "6", want: "4", wantOK: true},
+		{id: "7"},
+	}
+	for _, tt := range tests {
+		e, _ := ix.Employee(tt.id)
+		if got, ok := ix.SupervisorID(e); got != tt.want || ok != tt.wantOK {
+			t.Errorf("SupervisorID(%s) = %q, %v, want %q, %v", tt.id, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestSupervisorIDAmbiguousName(t *testing.T) {
+	ix := NewDirectoryIndex([]Employee{
+		{ID: "1", DisplayName: "Sam Smith"},
+		{ID: "2", DisplayName: "Sam Smith"},
+		{ID: "3", SupervisorName: "Sam Smith"},
+	})
+	e, _ := ix.Employee("3")
+	if id, ok := ix.SupervisorID(e); ok {
+		t.Errorf("SupervisorID = %q, want no match for a name shared by two employees", id)
+	}
+}
+
+func TestReportingChain(t *testing.T) {
+	ix := NewDirectoryIndex(mixedSupervisors)
+	chain, err := ix.ReportingChain("6")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, e := range chain {
+		ids = append(ids, e.ID)
+	}
+	if got, want := fmt.Sprint(ids), "[4 3 2 1]"; got != want {
+		t.Errorf("chain = %s, want %s", got, want)
+	}
+	if chain, err := ix.ReportingChain("1"); err != nil || len(chain) != 0 {
+		t.Errorf("chain for the top = %v, %v, want empty", chain, err)
+	}
+	if _, err := ix.ReportingChain("99"); err != ErrEmployeeNotFound {
+		t.Errorf("err = %v, want ErrEmployeeNotFound", err)
+	}
+
+	if reports := ix.DirectReports("4"); len(reports) != 2 || reports[0].ID != "5" || reports[1].ID != "6" {
+		t.Errorf("DirectReports(4) = %+v, want 5 and 6", reports)
+	}
+
+	loop := NewDirectoryIndex([]Employee{
+		{ID: "1", SupervisorEID: "3"},
+		{ID: "2", SupervisorName: "1"},
+		{ID: "3", SupervisorEID: "2"},
+	})
+	if _, err := loop.ReportingChain("1"); err == nil {
+		t.Error("no error for a loop")
+	}
+}
+
+func TestGetManagerEmail(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/employees/5":
+			w.Write([]byte(`{"id":"5","supervisorEId":null,"supervisor":"Lead, Di"}`))
+		case "/employees/1":
+			w.Write([]byte(`{"id":"1","supervisorEId":null,"supervisor":null}`))
+		case "/employees/directory":
+			w.Write([]byte(`{"fields":[],"employees":[` +
+				`{"id":"4","displayName":"Di Lead","firstName":"Di","lastName":"Lead","workEmail":"di@example.com"},` +
+				`{"id":"5","displayName":"Ed Engineer","supervisor":"Di Lead"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	ctx := context.Background()
+	if email, err := c.GetManagerEmail(ctx, "5"); err != nil || email != "di@example.com" {
+		t.Errorf("GetManagerEmail(5) = %q, %v, want di@example.com", email, err)
+	}
+	if _, err := c.GetManagerEmail(ctx, "1"); err != ErrNoManager {
+		t.Errorf("GetManagerEmail(1) err = %v, want ErrNoManager", err)
+	}
+	if _, err := c.GetManagerEmail(ctx, "9"); err != ErrEmployeeNotFound {
+		t.Errorf("GetManagerEmail(9) err = %v, want ErrEmployeeNotFound", err)
+	}
+}
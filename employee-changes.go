@@ -0,0 +1,188 @@
+package bamboohr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// ChangedEmployeesResponse is the top level response from the API
+type ChangedEmployeesResponse struct {
+	Latest    string                     `json:"latest"`
+	Employees map[string]ChangedEmployee `json:"employees"`
+}
+
+// ChangedEmployee represents an employee that has been inserted, updated or deleted
+type ChangedEmployee struct {
+	ID          string `json:"id"`
+	Action      string `json:"action"`
+	LastChanged string `json:"lastChanged"`
+}
+
+// ChangeType limits the employees returned by GetEmployeesChangedSince to a particular kind of change
+type ChangeType string
+
+// Change types for GetEmployeesChangedSince
+const (
+	ChangeTypeAll      ChangeType = ""
+	ChangeTypeInserted ChangeType = "inserted"
+	ChangeTypeUpdated  ChangeType = "updated"
+	ChangeTypeDeleted  ChangeType = "deleted"
+)
+
+// GetEmployeesChangedSince returns the employees that have changed since the given time, ordered by ID.
+// Use ChangeTypeAll to include every kind of change.
+func (c *Client) GetEmployeesChangedSince(ctx context.Context, since time.Time, changeType ChangeType) ([]ChangedEmployee, error) {
+	url := fmt.Sprintf("%s/employees/changed/", c.BaseURL)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	q := req.URL.Query()
+	q.Add("since", since.Format(time.RFC3339))
+	if changeType != "" {
+		q.Add("type", string(changeType))
+	}
+	req.URL.RawQuery = q.Encode()
+	req = req.WithContext(ctx)
+	cr := ChangedEmployeesResponse{}
+	if err := c.makeRequest(req, &cr); err != nil {
+		return nil, err
+	}
+	changed := make([]ChangedEmployee, 0, len(cr.Employees))
+	for _, e := range cr.Employees {
+		changed = append(changed, e)
+	}
+	sort.Slice(changed, func(i, j int) bool { return changed[i].ID < changed[j].ID })
+	return changed, nil
+}
+
+// GetAllEmployeesChangedSince requests the inserted, updated and deleted employees separately and merges them,
+// returning one entry per employee ordered by ID.  Where an employee appears for more than one type of change the
+// entry with the latest LastChanged wins, and if those are equal the later action in the order inserted, updated,
+// deleted wins, so an employee that was inserted and then deleted is reported as deleted.
+func (c *Client) GetAllEmployeesChangedSince(ctx context.Context, since time.Time) ([]ChangedEmployee, error) {
+	latest := map[string]ChangedEmployee{}
+	latestAt := map[string]time.Time{}
+	for _, changeType := range []ChangeType{ChangeTypeInserted, ChangeTypeUpdated, ChangeTypeDeleted} {
+		changed, err := c.GetEmployeesChangedSince(ctx, since, changeType)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range changed {
+			at, err := time.Parse(time.RFC3339, e.LastChanged)
+			if err != nil {
+				return nil, err
+			}
+			if prev, ok := latestAt[e.ID]; ok && at.Before(prev) {
+				continue
+			}
+			latest[e.ID] = e
+			latestAt[e.ID] = at
+		}
+	}
+	merged := make([]ChangedEmployee, 0, len(latest))
+	for _, e := range latest {
+		merged = append(merged, e)
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].ID < merged[j].ID })
+	return merged, nil
+}
+
+// ChangelogEntry records that an employee record was changed and when
+type ChangelogEntry struct {
+	EmployeeID string
+	Action     string
+	Time       time.Time
+}
+
+// GetChangelog returns the employee changes since the given time, oldest first, for use as an audit trail.
+//
+// Bamboo has no audit log endpoint, so this is built on GetEmployeesChangedSince and has the same limits:
+// only the latest change per employee is reported, there is no detail of which fields changed or who changed
+// them, and changes to anything other than employee records (e.g. files or time off) are not included.
+func (c *Client) GetChangelog(ctx context.Context, since time.Time) ([]ChangelogEntry, error) {
+	changed, err := c.GetEmployeesChangedSince(ctx, since, ChangeTypeAll)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]ChangelogEntry, 0, len(changed))
+	for _, e := range changed {
+		t, err := time.Parse(time.RFC3339, e.LastChanged)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, ChangelogEntry{EmployeeID: e.ID, Action: e.Action, Time: t})
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Time.Before(entries[j].Time) })
+	return entries, nil
+}
+
+// ChangeEvent is a change to an employee reported by WatchChanges, or an error polling for changes
+type ChangeEvent struct {
+	EmployeeID string
+	Action     string
+	Time       time.Time
+	// Err is set, and the other fields empty, when polling failed.  Polling carries on at the next interval.
+	Err error
+}
+
+// WatchChanges polls GetEmployeesChangedSince every interval, starting immediately, and sends each employee change
+// on the returned channel, which is closed once the context is done.  Only changes after WatchChanges is called are
+// sent, and the latest change time seen is used for the next poll, so nothing is missed between polls.
+//
+// Delivery is at least once.  Changes at the time polled from aren't sent again, but an employee that is changed
+// again before a poll is only reported once with their latest change, and changes are sent again if the process
+// restarts, so consumers should treat events for the same employee and time as duplicates.
+func (c *Client) WatchChanges(ctx context.Context, interval time.Duration) (<-chan ChangeEvent, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("interval must be positive, got %s", interval)
+	}
+	events := make(chan ChangeEvent)
+	go func() {
+		defer close(events)
+		since := time.Now().UTC().Truncate(time.Second)
+		// employees already sent with a change at since
+		sent := map[string]bool{}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			changes, err := c.GetChangelog(ctx, since)
+			if err != nil && ctx.Err() == nil && !sendChangeEvent(ctx, events, ChangeEvent{Err: err}) {
+				return
+			}
+			for _, change := range changes {
+				if change.Time.Before(since) || (change.Time.Equal(since) && sent[change.EmployeeID]) {
+					continue
+				}
+				event := ChangeEvent{EmployeeID: change.EmployeeID, Action: change.Action, Time: change.Time}
+				if !sendChangeEvent(ctx, events, event) {
+					return
+				}
+				if change.Time.After(since) {
+					since = change.Time
+					sent = map[string]bool{}
+				}
+				sent[change.EmployeeID] = true
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return events, nil
+}
+
+// sendChangeEvent sends an event, returning false if the context is done first
+func sendChangeEvent(ctx context.Context, events chan<- ChangeEvent, event ChangeEvent) bool {
+	select {
+	case events <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,55 @@
+package bamboohr
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGetAllEmployeesChangedSince(t *testing.T) {
+	responses := map[string]string{
+		"inserted": `{"latest":"2024-03-05T10:00:00+00:00","employees":{` +
+			`"1":{"id":"1","action":"Inserted","lastChanged":"2024-03-01T09:00:00+00:00"},` +
+			`"2":{"id":"2","action":"Inserted","lastChanged":"2024-03-02T09:00:00+00:00"},` +
+			`"3":{"id":"3","action":"Inserted","lastChanged":"2024-03-04T09:00:00+00:00"},` +
+			`"4":{"id":"4","action":"Inserted","lastChanged":"2024-03-03T09:00:00+00:00"}}}`,
+		"updated": `{"latest":"2024-03-05T10:00:00+00:00","employees":{` +
+			`"2":{"id":"2","action":"Updated","lastChanged":"2024-03-05T09:00:00+00:00"},` +
+			`"3":{"id":"3","action":"Updated","lastChanged":"2024-03-01T09:00:00+00:00"},` +
+			`"5":{"id":"5","action":"Updated","lastChanged":"2024-03-02T09:00:00+00:00"}}}`,
+		"deleted": `{"latest":"2024-03-05T10:00:00+00:00","employees":{` +
+			`"4":{"id":"4","action":"Deleted","lastChanged":"2024-03-03T09:00:00+00:00"}}}`,
+	}
+	var types []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		changeType := r.URL.Query().Get("type")
+		types = append(types, changeType)
+		if r.URL.Query().Get("since") != "2024-03-01T00:00:00Z" {
+			t.Errorf("since = %q", r.URL.Query().Get("since"))
+		}
+		w.Write([]byte(responses[changeType]))
+	})
+	since := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	changed, err := c.GetAllEmployeesChangedSince(context.Background(), since)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []ChangedEmployee{
+		{ID: "1", Action: "Inserted", LastChanged: "2024-03-01T09:00:00+00:00"},
+		// updated later than inserted
+		{ID: "2", Action: "Updated", LastChanged: "2024-03-05T09:00:00+00:00"},
+		// inserted later than the update
+		{ID: "3", Action: "Inserted", LastChanged: "2024-03-04T09:00:00+00:00"},
+		// deleted at the same time as inserted
+		{ID: "4", Action: "Deleted", LastChanged: "2024-03-03T09:00:00+00:00"},
+		{ID: "5", Action: "Updated", LastChanged: "2024-03-02T09:00:00+00:00"},
+	}
+	if !reflect.DeepEqual(changed, want) {
+		t.Errorf("got %+v, want %+v", changed, want)
+	}
+	if want := []string{"inserted", "updated", "deleted"}; !reflect.DeepEqual(types, want) {
+		t.Errorf("requested types %q, want %q", types, want)
+	}
+}
@@ -0,0 +1,127 @@
+package bamboohr
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FieldChange describes a field that differs between two employees
+type FieldChange struct {
+	Field EmployeeField
+	Old   string
+	New   string
+}
+
+// Diff returns the fields that differ between a and b, in the order they appear in Employee.
+// Pointer fields compare by value, with nil treated as different from any value, and the ID isn't compared.
+func Diff(a, b Employee) []FieldChange {
+	av, bv := a.fieldValues(), b.fieldValues()
+	var changes []FieldChange
+	for i := range av {
+		if av[i].value != bv[i].value || av[i].set != bv[i].set {
+			changes = append(changes, FieldChange{Field: av[i].field, Old: av[i].value, New: bv[i].value})
+		}
+	}
+	return changes
+}
+
+// SyncEmployee updates a specific employee so the given fields match desired, sending only the fields that differ.
+// If no fields are given the same fields as GetEmployee are compared, but only those set in desired are sent, so
+// fields left empty in desired are never cleared.  To clear a field, name it in fields.  The photo fields are read
+// only, so they're never sent.  No update is made if nothing differs.
+func (c *Client) SyncEmployee(ctx context.Context, id string, desired Employee, fields ...EmployeeField) (bool, error) {
+	current, err := c.GetEmployee(ctx, id, fields...)
+	if err != nil {
+		return false, err
+	}
+	wanted := map[EmployeeField]bool{}
+	for _, f := range fields {
+		wanted[f] = true
+	}
+	if len(fields) == 0 {
+		for _, fv := range desired.fieldValues() {
+			wanted[fv.field] = fv.set
+		}
+	}
+	updates := map[EmployeeField]string{}
+	for _, change := range Diff(current, desired) {
+		if wanted[change.Field] && !readOnlyEmployeeFields[change.Field] {
+			updates[change.Field] = change.New
+		}
+	}
+	if len(updates) == 0 {
+		return false, nil
+	}
+	if err := c.UpdateEmployee(ctx, id, updates); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// readOnlyEmployeeFields are the fields of Employee that Bamboo doesn't allow to be updated
+var readOnlyEmployeeFields = map[EmployeeField]bool{
+	PhotoUploaded:  true,
+	PhotoURL:       true,
+	CanUploadPhoto: true,
+}
+
+// employeeFieldNames holds the fields of Employee that aren't requested using their own name
+var employeeFieldNames = map[string]EmployeeField{
+	"EmploymentStatus": EmploymentStatusField,
+	"Zip":              Zipcode,
+	"SupervisorEID":    SupervisorEID,
+	"SupervisorName":   Supervisor,
+}
+
+// employeeFieldValue is the value of one of an employee's fields as a string
+type employeeFieldValue struct {
+	field EmployeeField
+	value string
+	// set is false for empty strings and nil pointers
+	set bool
+}
+
+// fieldValues returns the value of each of the employee's fields, other than the ID, in the order they appear in Employee.
+func (e Employee) fieldValues() []employeeFieldValue {
+	v := reflect.ValueOf(e)
+	t := v.Type()
+	values := make([]employeeFieldValue, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" || sf.Name == "ID" {
+			continue
+		}
+		fv := employeeFieldValue{field: EmployeeField(sf.Name)}
+		if f, ok := employeeFieldNames[sf.Name]; ok {
+			fv.field = f
+		}
+		switch f := v.Field(i); f.Kind() {
+		case reflect.Ptr:
+			if !f.IsNil() {
+				fv.value, fv.set = fmt.Sprint(f.Elem().Interface()), true
+			}
+		case reflect.String:
+			fv.value = f.String()
+			fv.set = fv.value != ""
+		default:
+			continue
+		}
+		values = append(values, fv)
+	}
+	return values
+}
+
+// employeeJSONFields returns the JSON names of every field in Employee, which are also the field aliases in reports.
+func employeeJSONFields() []string {
+	t := reflect.TypeOf(Employee{})
+	fields := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
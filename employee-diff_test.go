@@ -0,0 +1,129 @@
+package bamboohr
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestSyncEmployee(t *testing.T) {
+	const current = `{"id":"1","firstName":"Jo","lastName":"Bloggs","jobTitle":"Eng","workEmail":"jo@example.com",` +
+		`"photoUrl":"https://example.com/jo.jpg","photoUploaded":true,"canUploadPhoto":1}`
+	tests := []struct {
+		name    string
+		desired Employee
+		fields  []EmployeeField
+		// body of the update, empty if there shouldn't be one
+		body string
+	}{
+		{
+			name:    "only set fields sent without fields",
+			desired: Employee{JobTitle: "Senior Eng"},
+			body:    `{"JobTitle":"Senior Eng"}`,
+		},
+		{
+			name:    "nothing differs",
+			desired: Employee{FirstName: "Jo", JobTitle: "Eng"},
+		},
+		{
+			name:    "named fields can be cleared",
+			desired: Employee{JobTitle: "Senior Eng"},
+			fields:  []EmployeeField{JobTitle, WorkEmail},
+			body:    `{"JobTitle":"Senior Eng","WorkEmail":""}`,
+		},
+		{
+			name:    "unnamed fields ignored",
+			desired: Employee{FirstName: "Joanne", JobTitle: "Senior Eng"},
+			fields:  []EmployeeField{JobTitle},
+			body:    `{"JobTitle":"Senior Eng"}`,
+		},
+		{
+			name:    "photo fields never sent",
+			desired: Employee{PhotoURL: "https://example.com/new.jpg", PhotoUploaded: new(bool), CanUploadPhoto: new(int)},
+			fields:  []EmployeeField{PhotoURL, PhotoUploaded, CanUploadPhoto},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body string
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == "POST" {
+					b, _ := ioutil.ReadAll(r.Body)
+					body = string(b)
+					return
+				}
+				w.Write([]byte(current))
+			})
+			changed, err := c.SyncEmployee(context.Background(), "1", tt.desired, tt.fields...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if changed != (tt.body != "") {
+				t.Errorf("changed = %v, want %v", changed, tt.body != "")
+			}
+			if body != tt.body {
+				t.Errorf("body = %s, want %s", body, tt.body)
+			}
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	base := Employee{ID: "1", FirstName: "Jo", JobTitle: "Eng", PhotoUploaded: boolPtr(true), CanUploadPhoto: intPtr(1)}
+	tests := []struct {
+		name string
+		a, b Employee
+		want []FieldChange
+	}{
+		{name: "identical", a: base, b: base},
+		{name: "different ID ignored", a: base, b: func() Employee { e := base; e.ID = "2"; return e }()},
+		{
+			name: "string fields in struct order",
+			a:    base,
+			b:    func() Employee { e := base; e.JobTitle = "Senior Eng"; e.FirstName = ""; return e }(),
+			want: []FieldChange{{Field: FirstName, Old: "Jo"}, {Field: JobTitle, Old: "Eng", New: "Senior Eng"}},
+		},
+		{
+			name: "named types and renamed fields",
+			a:    Employee{},
+			b:    Employee{MaritalStatus: MaritalStatusMarried, EmploymentStatus: EmploymentStatusFullTime, Zip: "84042"},
+			want: []FieldChange{
+				{Field: MaritalStatusField, New: "Married"},
+				{Field: EmploymentStatusField, New: "Full-Time"},
+				{Field: Zipcode, New: "84042"},
+			},
+		},
+		{
+			name: "pointers compared by value",
+			a:    base,
+			b:    func() Employee { e := base; e.PhotoUploaded = boolPtr(true); e.CanUploadPhoto = intPtr(1); return e }(),
+		},
+		{
+			name: "pointer values differ",
+			a:    base,
+			b:    func() Employee { e := base; e.PhotoUploaded = boolPtr(false); e.CanUploadPhoto = intPtr(0); return e }(),
+			want: []FieldChange{
+				{Field: PhotoUploaded, Old: "true", New: "false"},
+				{Field: CanUploadPhoto, Old: "1", New: "0"},
+			},
+		},
+		{
+			name: "nil differs from zero value",
+			a:    Employee{PhotoUploaded: boolPtr(false)},
+			b:    Employee{CanUploadPhoto: intPtr(0)},
+			want: []FieldChange{
+				{Field: PhotoUploaded, Old: "false"},
+				{Field: CanUploadPhoto, New: "0"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Diff(tt.a, tt.b); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Diff = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
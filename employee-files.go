@@ -3,46 +3,97 @@ package bamboohr
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 // EmployeeCategoryResponse is the top level response from the API
 type EmployeeCategoryResponse struct {
 	EmployeeID struct {
-		ID int
+		ID int `json:"id"`
 	} `json:"employee"`
-	Categories []EmployeeCategory
+	Categories []EmployeeCategory `json:"categories"`
 }
 
 // EmployeeCategory represents a files category (or folder!)
 type EmployeeCategory struct {
-	ID                int
-	Name              string
-	CanRenameCategory string
-	CanDeleteCategory string
-	CanUploadFiles    string
-	DisplayIfEmpty    string
-	Files             []File
+	ID                int    `json:"id"`
+	Name              string `json:"name"`
+	CanRenameCategory string `json:"canRenameCategory"`
+	CanDeleteCategory string `json:"canDeleteCategory"`
+	CanUploadFiles    string `json:"canUploadFiles"`
+	DisplayIfEmpty    string `json:"displayIfEmpty"`
+	Files             []File `json:"files"`
 }
 
 // File represents an individual file
 type File struct {
-	ID                int
-	Name              string
-	OriginalFileName  string
-	Size              int
-	DateCreated       string
-	CreatedBy         string
-	ShareWithEmployee string
+	ID                int    `json:"id"`
+	Name              string `json:"name"`
+	OriginalFileName  string `json:"originalFileName"`
+	Size              int    `json:"size"`
+	DateCreated       string `json:"dateCreated"`
+	CreatedBy         string `json:"createdBy"`
+	ShareWithEmployee string `json:"shareWithEmployee"`
+}
+
+// UnmarshalJSON decodes the response, accepting the employee's ID as either a number or a string
+func (r *EmployeeCategoryResponse) UnmarshalJSON(b []byte) error {
+	type response EmployeeCategoryResponse // without this method, to avoid recursion
+	aux := struct {
+		*response
+		EmployeeID struct {
+			ID flexibleInt `json:"id"`
+		} `json:"employee"`
+	}{response: (*response)(r)}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+	r.EmployeeID.ID = aux.EmployeeID.ID.orZero()
+	return nil
+}
+
+// UnmarshalJSON decodes a category, accepting its ID as either a number or a string
+func (ec *EmployeeCategory) UnmarshalJSON(b []byte) error {
+	type category EmployeeCategory // without this method, to avoid recursion
+	aux := struct {
+		*category
+		ID flexibleInt `json:"id"`
+	}{category: (*category)(ec)}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+	ec.ID = aux.ID.orZero()
+	return nil
+}
+
+// UnmarshalJSON decodes a file, accepting its ID and size as either numbers or strings
+func (f *File) UnmarshalJSON(b []byte) error {
+	type file File // without this method, to avoid recursion
+	aux := struct {
+		*file
+		ID   flexibleInt `json:"id"`
+		Size flexibleInt `json:"size"`
+	}{file: (*file)(f)}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+	f.ID = aux.ID.orZero()
+	f.Size = aux.Size.orZero()
+	return nil
 }
 
 // GetEmployeeFilesAndCategories returns a list of employee files and categories
 func (c *Client) GetEmployeeFilesAndCategories(ctx context.Context, id string) ([]EmployeeCategory, error) {
+	if err := validateEmployeeID(id); err != nil {
+		return nil, err
+	}
 	url := fmt.Sprintf("%s/employees/%s/files/view/", c.BaseURL, id)
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
@@ -59,6 +110,9 @@ func (c *Client) GetEmployeeFilesAndCategories(ctx context.Context, id string) (
 // UploadEmployeeFile uploads a file to a specific employees files under the given category ID.
 // Beware the inconsistent ID types Bamboo uses.  We require all strings here.
 func (c *Client) UploadEmployeeFile(ctx context.Context, employeeID, categoryID, fileName, filePath, share string) error {
+	if err := validateEmployeeID(employeeID); err != nil {
+		return err
+	}
 
 	file, err := os.Open(filePath)
 	defer file.Close()
@@ -108,3 +162,64 @@ func (c *Client) UploadEmployeeFile(ctx context.Context, employeeID, categoryID,
 	}
 	return nil
 }
+
+// FileCategory describes a files category without its files
+type FileCategory struct {
+	ID                int
+	Name              string
+	CanRenameCategory string
+	CanDeleteCategory string
+	CanUploadFiles    string
+	DisplayIfEmpty    string
+}
+
+// GetEmployeeFileCategories returns the categories employee files can be uploaded to.  Categories are the same for
+// every employee, so they're taken from the files of the user that created the API Key.
+func (c *Client) GetEmployeeFileCategories(ctx context.Context) ([]FileCategory, error) {
+	categories, err := c.GetEmployeeFilesAndCategories(ctx, "0")
+	if err != nil {
+		return nil, err
+	}
+	return fileCategories(categories), nil
+}
+
+// GetCompanyFileCategories returns the categories company files can be uploaded to
+func (c *Client) GetCompanyFileCategories(ctx context.Context) ([]FileCategory, error) {
+	url := fmt.Sprintf("%s/files/view/", c.BaseURL)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	ec := EmployeeCategoryResponse{}
+	if err := c.makeRequest(req, &ec); err != nil {
+		return nil, err
+	}
+	return fileCategories(ec.Categories), nil
+}
+
+// FindFileCategory returns the category with the given name, ignoring case
+func FindFileCategory(categories []FileCategory, name string) (FileCategory, bool) {
+	for _, category := range categories {
+		if strings.EqualFold(category.Name, name) {
+			return category, true
+		}
+	}
+	return FileCategory{}, false
+}
+
+// fileCategories drops the files from a list of categories
+func fileCategories(categories []EmployeeCategory) []FileCategory {
+	fc := make([]FileCategory, 0, len(categories))
+	for _, c := range categories {
+		fc = append(fc, FileCategory{
+			ID:                c.ID,
+			Name:              c.Name,
+			CanRenameCategory: c.CanRenameCategory,
+			CanDeleteCategory: c.CanDeleteCategory,
+			CanUploadFiles:    c.CanUploadFiles,
+			DisplayIfEmpty:    c.DisplayIfEmpty,
+		})
+	}
+	return fc
+}
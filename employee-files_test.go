@@ -0,0 +1,60 @@
+package bamboohr
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestGetEmployeeFilesAndCategoriesNumbers(t *testing.T) {
+	want := []EmployeeCategory{{
+		ID:                16,
+		Name:              "Signed Documents",
+		CanRenameCategory: "no",
+		CanDeleteCategory: "no",
+		CanUploadFiles:    "yes",
+		DisplayIfEmpty:    "yes",
+		Files: []File{{
+			ID:                1234,
+			Name:              "Employee Handbook",
+			OriginalFileName:  "handbook.pdf",
+			Size:              23456,
+			DateCreated:       "2021-04-01 10:00:00",
+			CreatedBy:         "Jo Bloggs",
+			ShareWithEmployee: "yes",
+		}},
+	}}
+	for _, name := range []string{"files-number-ids.json", "files-string-ids.json"} {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t, serveFixture(t, name))
+			c.StrictJSON = true
+			categories, err := c.GetEmployeeFilesAndCategories(context.Background(), "123")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(categories, want) {
+				t.Errorf("got %+v, want %+v", categories, want)
+			}
+		})
+	}
+}
+
+func TestGetEmployeeFilesAndCategoriesFixture(t *testing.T) {
+	c := newTestClient(t, serveFixture(t, "files.json"))
+	c.StrictJSON = true
+	categories, err := c.GetEmployeeFilesAndCategories(context.Background(), "124")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(categories) != 2 {
+		t.Fatalf("got %d categories, want 2", len(categories))
+	}
+	if files := categories[0].Files; len(files) != 2 || files[1].ID != 1235 || files[1].Size != 10240 ||
+		files[1].OriginalFileName != "offer.docx" || files[1].ShareWithEmployee != "no" {
+		t.Errorf("Signed Documents files = %+v", files)
+	}
+	if training := categories[1]; training.ID != 17 || training.Name != "Training" || training.DisplayIfEmpty != "no" ||
+		training.Files == nil || len(training.Files) != 0 {
+		t.Errorf("Training category = %+v, want no files", training)
+	}
+}
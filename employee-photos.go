@@ -0,0 +1,156 @@
+package bamboohr
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+)
+
+// defaultMaxPhotoDownloadSize is used by UploadEmployeePhotoFromURL when the Client doesn't set MaxPhotoDownloadSize
+const defaultMaxPhotoDownloadSize = 5 << 20
+
+// GetEmployeePhoto returns the photo for a specific employee in the given size, one of "original", "large",
+// "medium", "small", "xs" or "tiny".
+func (c *Client) GetEmployeePhoto(ctx context.Context, employeeID, size string) ([]byte, error) {
+	if err := validateEmployeeID(employeeID); err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf("%s/employees/%s/photo/%s", c.BaseURL, employeeID, size)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "image/*")
+	req = req.WithContext(ctx)
+	res, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	return ioutil.ReadAll(res.Body)
+}
+
+// GetEmployeePhotos returns the photos for many employees concurrently, keyed by employee ID, along with the errors
+// for any that failed.  Employees the last GetEmployeeDirectory response showed as having no photo are skipped and
+// get ErrNoPhoto in the errors, so call GetEmployeeDirectory first to avoid requesting photos that don't exist.
+// Every photo is held in memory, so for large numbers of employees prefer a small size or fetch them in batches.
+// The error is only non-nil when the photos could not be requested at all, e.g. the context is already done.
+func (c *Client) GetEmployeePhotos(ctx context.Context, ids []string, size string) (map[string][]byte, map[string]error, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	noPhoto := map[string]bool{}
+	c.directory.mu.Lock()
+	for _, e := range c.directory.employees {
+		if e.PhotoUploaded != nil && !*e.PhotoUploaded {
+			noPhoto[e.ID] = true
+		}
+	}
+	c.directory.mu.Unlock()
+
+	var mu sync.Mutex
+	photos := map[string][]byte{}
+	wanted := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if !noPhoto[id] {
+			wanted = append(wanted, id)
+		}
+	}
+	errs := forEachConcurrently(ctx, wanted, func(id string) error {
+		photo, err := c.GetEmployeePhoto(ctx, id, size)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		photos[id] = photo
+		mu.Unlock()
+		return nil
+	})
+	for _, id := range ids {
+		if noPhoto[id] {
+			errs[id] = ErrNoPhoto
+		}
+	}
+	return photos, errs, nil
+}
+
+// UploadEmployeePhoto uploads a photo for a specific employee.  Bamboo requires the image to be square and at least 150px.
+func (c *Client) UploadEmployeePhoto(ctx context.Context, employeeID, fileName string, photo []byte) error {
+	if err := validateEmployeeID(employeeID); err != nil {
+		return err
+	}
+	payload := &bytes.Buffer{}
+	writer := multipart.NewWriter(payload)
+	part, err := writer.CreateFormFile("file", fileName)
+	if err != nil {
+		return err
+	}
+	if _, err = part.Write(photo); err != nil {
+		return err
+	}
+	if err = writer.Close(); err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/employees/%s/photo", c.BaseURL, employeeID)
+	req, err := http.NewRequest("POST", url, payload)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req = req.WithContext(ctx)
+	return c.makeRequest(req, nil)
+}
+
+// UploadEmployeePhotoFromURL downloads an image and uploads it as the photo for a specific employee.
+// The download uses the Client's HTTPClient and is limited to MaxPhotoDownloadSize bytes.
+func (c *Client) UploadEmployeePhotoFromURL(ctx context.Context, employeeID, imageURL string) error {
+	if err := validateEmployeeID(employeeID); err != nil {
+		return err
+	}
+	req, err := http.NewRequest("GET", imageURL, nil)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("error downloading photo, status code: %d", res.StatusCode)
+	}
+
+	limit := c.MaxPhotoDownloadSize
+	if limit <= 0 {
+		limit = defaultMaxPhotoDownloadSize
+	}
+	photo, err := ioutil.ReadAll(io.LimitReader(res.Body, limit+1))
+	if err != nil {
+		return err
+	}
+	if int64(len(photo)) > limit {
+		return fmt.Errorf("photo is larger than %d bytes", limit)
+	}
+	contentType := res.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = http.DetectContentType(photo)
+	}
+	if !strings.HasPrefix(contentType, "image/") {
+		return fmt.Errorf("photo is not an image, content type: %s", contentType)
+	}
+
+	fileName := path.Base(req.URL.Path)
+	if fileName == "/" || fileName == "." {
+		fileName = "photo"
+	}
+	return c.UploadEmployeePhoto(ctx, employeeID, fileName, photo)
+}
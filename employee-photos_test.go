@@ -0,0 +1,48 @@
+package bamboohr
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+func TestGetEmployeePhotos(t *testing.T) {
+	var mu sync.Mutex
+	requested := map[string]bool{}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requested[r.URL.Path] = true
+		mu.Unlock()
+		switch r.URL.Path {
+		case "/employees/directory":
+			w.Write([]byte(`{"fields":[],"employees":[{"id":"1","photoUploaded":true},{"id":"2","photoUploaded":false}]}`))
+		case "/employees/1/photo/small":
+			w.Write([]byte("jpeg"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	if _, err := c.GetEmployeeDirectory(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	photos, errs, err := c.GetEmployeePhotos(context.Background(), []string{"1", "2", "3"}, "small")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(photos) != 1 || string(photos["1"]) != "jpeg" {
+		t.Errorf("photos = %v, want only 1", photos)
+	}
+	if errs["2"] != ErrNoPhoto {
+		t.Errorf("errs[2] = %v, want ErrNoPhoto", errs["2"])
+	}
+	if requested["/employees/2/photo/small"] {
+		t.Error("photo requested for employee without one")
+	}
+	if !isStatus(errs["3"], http.StatusNotFound) {
+		t.Errorf("errs[3] = %v, want a 404", errs["3"])
+	}
+	if _, ok := errs["1"]; ok || len(errs) != 2 {
+		t.Errorf("errs = %v, want 2 and 3 only", errs)
+	}
+}
@@ -0,0 +1,126 @@
+package bamboohr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"gopkg.in/errgo.v2/errors"
+)
+
+// MaritalStatus is an employee's marital status.  Tenants can add their own values, which are kept as they are
+// returned from Bamboo, so use Known to check whether the value is one of the standard ones below.
+type MaritalStatus string
+
+// Standard marital status values
+const (
+	MaritalStatusSingle              MaritalStatus = "Single"
+	MaritalStatusMarried             MaritalStatus = "Married"
+	MaritalStatusCommonLaw           MaritalStatus = "Common Law"
+	MaritalStatusDomesticPartnership MaritalStatus = "Domestic Partnership"
+	MaritalStatusDivorced            MaritalStatus = "Divorced"
+	MaritalStatusSeparated           MaritalStatus = "Separated"
+	MaritalStatusWidowed             MaritalStatus = "Widowed"
+)
+
+// Known reports whether the marital status is one of the standard values
+func (s MaritalStatus) Known() bool {
+	switch s {
+	case MaritalStatusSingle, MaritalStatusMarried, MaritalStatusCommonLaw, MaritalStatusDomesticPartnership,
+		MaritalStatusDivorced, MaritalStatusSeparated, MaritalStatusWidowed:
+		return true
+	}
+	return false
+}
+
+// String returns the value exactly as Bamboo returned it
+func (s MaritalStatus) String() string {
+	return string(s)
+}
+
+// UnmarshalJSON accepts the value as a string, leaving it empty when Bamboo returns null
+func (s *MaritalStatus) UnmarshalJSON(b []byte) error {
+	var v *string
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*s = ""
+	if v != nil {
+		*s = MaritalStatus(*v)
+	}
+	return nil
+}
+
+// EmploymentStatus is an employee's employment status.  Tenants can add their own values, which are kept as they
+// are returned from Bamboo, so use Known to check whether the value is one of the standard ones below.
+type EmploymentStatus string
+
+// Standard employment status values
+const (
+	EmploymentStatusFullTime   EmploymentStatus = "Full-Time"
+	EmploymentStatusPartTime   EmploymentStatus = "Part-Time"
+	EmploymentStatusContractor EmploymentStatus = "Contractor"
+	EmploymentStatusIntern     EmploymentStatus = "Intern"
+	EmploymentStatusTerminated EmploymentStatus = "Terminated"
+)
+
+// Known reports whether the employment status is one of the standard values
+func (s EmploymentStatus) Known() bool {
+	switch s {
+	case EmploymentStatusFullTime, EmploymentStatusPartTime, EmploymentStatusContractor, EmploymentStatusIntern,
+		EmploymentStatusTerminated:
+		return true
+	}
+	return false
+}
+
+// String returns the value exactly as Bamboo returned it
+func (s EmploymentStatus) String() string {
+	return string(s)
+}
+
+// UnmarshalJSON accepts the value as a string, leaving it empty when Bamboo returns null
+func (s *EmploymentStatus) UnmarshalJSON(b []byte) error {
+	var v *string
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*s = ""
+	if v != nil {
+		*s = EmploymentStatus(*v)
+	}
+	return nil
+}
+
+// ChangeEmploymentStatus records a change of employment status for an employee, effective from the given date, by
+// adding a row to their employmentStatus table.  Only the standard EmploymentStatus values are accepted.
+func (c *Client) ChangeEmploymentStatus(ctx context.Context, employeeID string, status EmploymentStatus, effectiveDate time.Time, comment string) error {
+	if err := validateEmployeeID(employeeID); err != nil {
+		return err
+	}
+	if effectiveDate.IsZero() {
+		return errors.New("effectiveDate required")
+	}
+	if !status.Known() {
+		return fmt.Errorf("unrecognised employment status: %q", status)
+	}
+	body, err := json.Marshal(map[string]string{
+		"date":             effectiveDate.Format("2006-01-02"),
+		"employmentStatus": string(status),
+		"comment":          comment,
+	})
+	if err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s/employees/%s/tables/employmentStatus", c.BaseURL, employeeID)
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(ctx)
+	return c.makeRequest(req, nil)
+}
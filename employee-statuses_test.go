@@ -0,0 +1,79 @@
+package bamboohr
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMaritalStatusUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		json  string
+		want  MaritalStatus
+		known bool
+	}{
+		{json: `"Single"`, want: MaritalStatusSingle, known: true},
+		{json: `"Domestic Partnership"`, want: MaritalStatusDomesticPartnership, known: true},
+		{json: `"Widowed"`, want: MaritalStatusWidowed, known: true},
+		{json: `"married"`, want: "married"},
+		{json: `"Civil Partnership"`, want: "Civil Partnership"},
+		{json: `""`, want: ""},
+		{json: `null`, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.json, func(t *testing.T) {
+			var s MaritalStatus
+			if err := json.Unmarshal([]byte(tt.json), &s); err != nil {
+				t.Fatal(err)
+			}
+			if s != tt.want || s.Known() != tt.known {
+				t.Errorf("got %q, known %v, want %q, known %v", s, s.Known(), tt.want, tt.known)
+			}
+			if s.String() != string(tt.want) {
+				t.Errorf("String() = %q, want %q", s.String(), tt.want)
+			}
+			b, err := json.Marshal(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var again MaritalStatus
+			if err := json.Unmarshal(b, &again); err != nil || again != s {
+				t.Errorf("round trip = %q, %v, want %q", again, err, s)
+			}
+		})
+	}
+	var s MaritalStatus
+	if err := json.Unmarshal([]byte(`1`), &s); err == nil {
+		t.Error("no error for a number")
+	}
+}
+
+func TestEmploymentStatusUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		json  string
+		want  EmploymentStatus
+		known bool
+	}{
+		{json: `"Full-Time"`, want: EmploymentStatusFullTime, known: true},
+		{json: `"Part-Time"`, want: EmploymentStatusPartTime, known: true},
+		{json: `"Contractor"`, want: EmploymentStatusContractor, known: true},
+		{json: `"Intern"`, want: EmploymentStatusIntern, known: true},
+		{json: `"Terminated"`, want: EmploymentStatusTerminated, known: true},
+		{json: `"Full Time"`, want: "Full Time"},
+		{json: `"Seasonal"`, want: "Seasonal"},
+		{json: `null`, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.json, func(t *testing.T) {
+			var s EmploymentStatus
+			if err := json.Unmarshal([]byte(tt.json), &s); err != nil {
+				t.Fatal(err)
+			}
+			if s != tt.want || s.Known() != tt.known {
+				t.Errorf("got %q, known %v, want %q, known %v", s, s.Known(), tt.want, tt.known)
+			}
+			if s.String() != string(tt.want) {
+				t.Errorf("String() = %q, want %q", s.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package bamboohr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// GetEmployeeTable retrieves the rows of a table, such as jobInfo or compensation, for a specific employee and
+// decodes them into v, which should be a pointer to a slice of a struct or map.  Struct fields are matched to the
+// table's columns by their json tags, which should be the column aliases as listed by GetTables, e.g.
+//
+//	type JobTitleRow struct {
+//		Date     string `json:"date"`
+//		JobTitle string `json:"jobTitle"`
+//	}
+//
+// Each row also includes its "id" and the "employeeId".
+func (c *Client) GetEmployeeTable(ctx context.Context, id, table string, v interface{}) error {
+	if err := validateEmployeeID(id); err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s/employees/%s/tables/%s", c.BaseURL, id, table)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	return c.makeRequest(req, v)
+}
+
+// JobInfo is a row of the jobInfo table, recording an employee's job from the given date
+type JobInfo struct {
+	ID         string `json:"id"`
+	EmployeeID string `json:"employeeId"`
+	Date       string `json:"date"`
+	Location   string `json:"location"`
+	Department string `json:"department"`
+	Division   string `json:"division"`
+	JobTitle   string `json:"jobTitle"`
+	ReportsTo  string `json:"reportsTo"`
+}
+
+// GetJobInfoHistory returns the rows of a specific employee's jobInfo table, oldest first
+func (c *Client) GetJobInfoHistory(ctx context.Context, employeeID string) ([]JobInfo, error) {
+	rows := []JobInfo{}
+	if err := c.GetEmployeeTable(ctx, employeeID, "jobInfo", &rows); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].Date < rows[j].Date })
+	return rows, nil
+}
+
+// GetCurrentJobInfo returns a specific employee's current job, the jobInfo row with the latest date that isn't
+// in the future.  ErrNoJobInfo is returned if there isn't one.
+func (c *Client) GetCurrentJobInfo(ctx context.Context, employeeID string) (JobInfo, error) {
+	rows, err := c.GetJobInfoHistory(ctx, employeeID)
+	if err != nil {
+		return JobInfo{}, err
+	}
+	today := time.Now().Format("2006-01-02")
+	for i := len(rows) - 1; i >= 0; i-- {
+		if rows[i].Date <= today {
+			return rows[i], nil
+		}
+	}
+	return JobInfo{}, ErrNoJobInfo
+}
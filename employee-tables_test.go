@@ -0,0 +1,74 @@
+package bamboohr
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestGetEmployeeTableCustomStruct(t *testing.T) {
+	type jobTitleRow struct {
+		Date     string `json:"date"`
+		JobTitle string `json:"jobTitle"`
+	}
+	var path string
+	fixture := serveFixture(t, "job-info.json")
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		fixture(w, r)
+	})
+	var rows []jobTitleRow
+	if err := c.GetEmployeeTable(context.Background(), "124", "jobInfo", &rows); err != nil {
+		t.Fatal(err)
+	}
+	if path != "/employees/124/tables/jobInfo" {
+		t.Errorf("requested %q", path)
+	}
+	want := []jobTitleRow{
+		{Date: "2021-06-01", JobTitle: "Senior Software Engineer"},
+		{Date: "2019-03-04", JobTitle: "Software Engineer"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("got %+v, want %+v", rows, want)
+	}
+}
+
+func TestGetEmployeeTableMaps(t *testing.T) {
+	c := newTestClient(t, serveFixture(t, "job-info.json"))
+	var rows []map[string]interface{}
+	if err := c.GetEmployeeTable(context.Background(), "124", "jobInfo", &rows); err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || rows[0]["reportsTo"] != "Jo Bloggs" || rows[1]["division"] != nil {
+		t.Errorf("got %+v", rows)
+	}
+}
+
+func TestGetJobInfoHistory(t *testing.T) {
+	c := newTestClient(t, serveFixture(t, "job-info.json"))
+	c.StrictJSON = true
+	rows, err := c.GetJobInfoHistory(context.Background(), "124")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []JobInfo{
+		{ID: "1", EmployeeID: "124", Date: "2019-03-04", Location: "Lindon, Utah", Department: "Engineering",
+			JobTitle: "Software Engineer", ReportsTo: "Sam Smith"},
+		{ID: "2", EmployeeID: "124", Date: "2021-06-01", Location: "Lindon, Utah", Department: "Engineering",
+			Division: "North America", JobTitle: "Senior Software Engineer", ReportsTo: "Jo Bloggs"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("got %+v, want %+v", rows, want)
+	}
+}
+
+func TestGetEmployeeTableInvalidID(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL)
+	})
+	var rows []JobInfo
+	if err := c.GetEmployeeTable(context.Background(), "../123", "jobInfo", &rows); err == nil {
+		t.Error("no error for an invalid ID")
+	}
+}
@@ -1,17 +1,30 @@
 package bamboohr
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
 	"strings"
-
-	"gopkg.in/errgo.v2/errors"
+	"time"
 )
 
 // EmployeeResponse is the top level response from the API
 type EmployeeResponse struct {
-	Employees []Employee
+	Fields    []DirectoryField `json:"fields"`
+	Employees []Employee       `json:"employees"`
+}
+
+// DirectoryField describes one of the fields included in the employee directory
+type DirectoryField struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	Name string `json:"name"`
 }
 
 // EmployeeFields holds a slice of EmployeeField which are fields that can be requested on GetEmployee
@@ -45,65 +58,461 @@ func (ef EmployeeFields) Join(sep string) string {
 
 // Fields for GetEmployee
 const (
-	DisplayName        EmployeeField = "DisplayName"
-	FirstName                        = "FirstName"
-	LastName                         = "LastName"
-	PreferredName                    = "PreferredName"
-	Gender                           = "Gender"
-	JobTitle                         = "JobTitle"
-	WorkPhone                        = "WorkPhone"
-	MobilePhone                      = "MobilePhone"
-	WorkEmail                        = "WorkEmail"
-	Department                       = "Department"
-	Location                         = "Location"
-	Division                         = "Division"
-	LinkedIn                         = "LinkedIn"
-	WorkPhoneExtension               = "WorkPhoneExtension"
-	PhotoUploaded                    = "PhotoUploaded"
-	PhotoURL                         = "PhotoURL"
-	CanUploadPhoto                   = "CanUploadPhoto"
-	HireDate                         = "HireDate"
-	ReportingTo                      = "Reporting to"
+	DisplayName           EmployeeField = "DisplayName"
+	FirstName                           = "FirstName"
+	LastName                            = "LastName"
+	PreferredName                       = "PreferredName"
+	Gender                              = "Gender"
+	JobTitle                            = "JobTitle"
+	WorkPhone                           = "WorkPhone"
+	MobilePhone                         = "MobilePhone"
+	WorkEmail                           = "WorkEmail"
+	Department                          = "Department"
+	Location                            = "Location"
+	Division                            = "Division"
+	LinkedIn                            = "LinkedIn"
+	WorkPhoneExtension                  = "WorkPhoneExtension"
+	PhotoUploaded                       = "PhotoUploaded"
+	PhotoURL                            = "PhotoURL"
+	CanUploadPhoto                      = "CanUploadPhoto"
+	HireDate                            = "HireDate"
+	ReportingTo                         = "Reporting to"
+	MaritalStatusField                  = "MaritalStatus"
+	EmploymentStatusField               = "EmploymentHistoryStatus"
+	Status                              = "Status"
+	Address1                            = "Address1"
+	Address2                            = "Address2"
+	City                                = "City"
+	State                               = "State"
+	Zipcode                             = "Zipcode"
+	Country                             = "Country"
+	HomeEmail                           = "HomeEmail"
+	SupervisorEID                       = "SupervisorEId"
+	Supervisor                          = "Supervisor"
 )
 
-// Employee represents a single person
+// AddressFields are the fields making up an employee's home address
+var AddressFields = EmployeeFields{Address1, Address2, City, State, Zipcode, Country}
+
+// defaultEmployeeFields are requested by GetEmployee when neither the caller nor the Client specify any fields
+var defaultEmployeeFields = EmployeeFields{DisplayName, FirstName, LastName, PreferredName, Gender, JobTitle, WorkPhone, MobilePhone, WorkEmail, Department, Location, Division, LinkedIn, WorkPhoneExtension, PhotoUploaded, PhotoURL, CanUploadPhoto, HireDate, MaritalStatusField, EmploymentStatusField, HomeEmail, Status, SupervisorEID, Supervisor}
+
+// Employee represents a single person.
+// Fields Bamboo returns as null or omits entirely are left as their zero value.
 type Employee struct {
-	ID                 string
-	DisplayName        string
-	FirstName          string
-	LastName           string
-	PreferredName      string
-	Gender             string
-	JobTitle           string
-	WorkPhone          string
-	MobilePhone        string
-	WorkEmail          string
-	Department         string
-	Location           string
-	Division           string
-	LinkedIn           string
-	WorkPhoneExtension string
-	PhotoUploaded      *bool // to avoid false when it's empty
-	PhotoURL           string
-	CanUploadPhoto     *int // to avoid 0 when it's empty
-	HireDate           string
+	ID                 string           `json:"id"`
+	DisplayName        string           `json:"displayName"`
+	FirstName          string           `json:"firstName"`
+	LastName           string           `json:"lastName"`
+	PreferredName      string           `json:"preferredName"`
+	Gender             string           `json:"gender"`
+	JobTitle           string           `json:"jobTitle"`
+	WorkPhone          string           `json:"workPhone"`
+	MobilePhone        string           `json:"mobilePhone"`
+	WorkEmail          string           `json:"workEmail"`
+	HomeEmail          string           `json:"homeEmail"`
+	Department         string           `json:"department"`
+	Location           string           `json:"location"`
+	Division           string           `json:"division"`
+	LinkedIn           string           `json:"linkedIn"`
+	WorkPhoneExtension string           `json:"workPhoneExtension"`
+	PhotoUploaded      *bool            `json:"photoUploaded"` // to avoid false when it's empty
+	PhotoURL           string           `json:"photoUrl"`
+	CanUploadPhoto     *int             `json:"canUploadPhoto"` // to avoid 0 when it's empty
+	HireDate           string           `json:"hireDate"`
+	Status             string           `json:"status"`
+	MaritalStatus      MaritalStatus    `json:"maritalStatus"`
+	EmploymentStatus   EmploymentStatus `json:"employmentHistoryStatus"`
+	Address1           string           `json:"address1"`
+	Address2           string           `json:"address2"`
+	City               string           `json:"city"`
+	State              string           `json:"state"`
+	Zip                string           `json:"zipcode"`
+	Country            string           `json:"country"`
+	SupervisorEID      string           `json:"supervisorEId"`
+	SupervisorName     string           `json:"supervisor"` // sometimes holds the ID, use a DirectoryIndex to resolve
+}
+
+// UnmarshalJSON decodes an employee, accepting CanUploadPhoto as either a number or a string since Bamboo
+// returns it differently depending on the endpoint.
+func (e *Employee) UnmarshalJSON(b []byte) error {
+	type employee Employee // without this method, to avoid recursion
+	aux := struct {
+		*employee
+		CanUploadPhoto flexibleInt `json:"canUploadPhoto"`
+	}{employee: (*employee)(e)}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+	if aux.CanUploadPhoto.present {
+		e.CanUploadPhoto = aux.CanUploadPhoto.value
+	}
+	return nil
+}
+
+// flexibleInt decodes an integer given as either a JSON number or string, with null or "" decoding as nil.
+type flexibleInt struct {
+	present bool
+	value   *int
+}
+
+// orZero returns the integer, or 0 if it was null or empty
+func (i flexibleInt) orZero() int {
+	if i.value == nil {
+		return 0
+	}
+	return *i.value
+}
+
+func (i *flexibleInt) UnmarshalJSON(b []byte) error {
+	i.present = true
+	i.value = nil
+	s := string(b)
+	if s == "null" {
+		return nil
+	}
+	if strings.HasPrefix(s, `"`) {
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		if s = strings.TrimSpace(s); s == "" {
+			return nil
+		}
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return fmt.Errorf("cannot decode %s as an integer", b)
+	}
+	i.value = &n
+	return nil
+}
+
+// FullWorkPhone returns the work phone number including the extension, if there is one, e.g. "+1 555-1234 x789".
+// An empty string is returned when there is no work phone number.
+func (e Employee) FullWorkPhone() string {
+	phone := strings.TrimSpace(e.WorkPhone)
+	if phone == "" {
+		return ""
+	}
+	if ext := strings.TrimSpace(e.WorkPhoneExtension); ext != "" {
+		return fmt.Sprintf("%s x%s", phone, ext)
+	}
+	return phone
+}
+
+// PhotoURLOrDefault returns the employee's photo URL, or defaultURL when they haven't uploaded a photo.
+// Bamboo still returns a placeholder PhotoURL for employees without a photo, and depending on the company's
+// settings photo URLs may require authentication, so only rely on PhotoURL when PhotoUploaded is true.
+func (e Employee) PhotoURLOrDefault(defaultURL string) string {
+	photoURL := strings.TrimSpace(e.PhotoURL)
+	if e.PhotoUploaded == nil || !*e.PhotoUploaded || photoURL == "" {
+		return defaultURL
+	}
+	return photoURL
+}
+
+// PreferredDisplayName returns the name most people want to show, the PreferredName (or FirstName if there
+// isn't one) followed by the LastName.  DisplayName is returned if none of those are set.
+func (e Employee) PreferredDisplayName() string {
+	first := strings.TrimSpace(e.PreferredName)
+	if first == "" {
+		first = strings.TrimSpace(e.FirstName)
+	}
+	name := strings.TrimSpace(first + " " + strings.TrimSpace(e.LastName))
+	if name == "" {
+		return e.DisplayName
+	}
+	return name
+}
+
+// ComposedAddress returns the employee's address on a single line, leaving out any parts that aren't set,
+// e.g. "1 Main Street, Springfield, IL 62701, United States"
+func (e Employee) ComposedAddress() string {
+	var parts []string
+	for _, p := range []string{
+		e.Address1,
+		e.Address2,
+		e.City,
+		strings.TrimSpace(strings.TrimSpace(e.State) + " " + strings.TrimSpace(e.Zip)),
+		e.Country,
+	} {
+		if p = strings.TrimSpace(p); p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// hireDate parses the HireDate in the location of asOf.  Bamboo returns "0000-00-00" when it isn't set.
+func (e Employee) hireDate(asOf time.Time) (time.Time, bool) {
+	hired, err := time.ParseInLocation("2006-01-02", e.HireDate, asOf.Location())
+	if err != nil || hired.Year() < 1 {
+		return time.Time{}, false
+	}
+	return hired, true
+}
+
+// Tenure returns how long the employee has worked for the company as of the given time.  Zero is returned when the
+// HireDate isn't set or is after asOf.
+func (e Employee) Tenure(asOf time.Time) time.Duration {
+	hired, ok := e.hireDate(asOf)
+	if !ok || hired.After(asOf) {
+		return 0
+	}
+	return asOf.Sub(hired)
+}
+
+// TenureYears returns the employee's tenure in years as of the given time, e.g. 2.5 half way between the second and
+// third anniversaries of their HireDate.  Years are counted between anniversaries, so leap years don't skew the
+// result, and an employee hired on the 29th of February has their anniversary on the 1st of March in other years.
+// Zero is returned when the HireDate isn't set or is after asOf.
+func (e Employee) TenureYears(asOf time.Time) float64 {
+	hired, ok := e.hireDate(asOf)
+	if !ok || hired.After(asOf) {
+		return 0
+	}
+	years := asOf.Year() - hired.Year()
+	if hired.AddDate(years, 0, 0).After(asOf) {
+		years--
+	}
+	anniversary, next := hired.AddDate(years, 0, 0), hired.AddDate(years+1, 0, 0)
+	return float64(years) + float64(asOf.Sub(anniversary))/float64(next.Sub(anniversary))
+}
+
+// String returns a short summary of the employee that is safe to log, e.g. `Employee{ID: "123", DisplayName: "Jane
+// Doe", JobTitle: "Engineer"}`.  Only the ID, DisplayName and JobTitle are included, since HR data such as phone
+// numbers, email and home addresses shouldn't end up in logs.  Use FullString when everything is really needed.
+func (e Employee) String() string {
+	return fmt.Sprintf("Employee{ID: %q, DisplayName: %q, JobTitle: %q}", e.ID, e.DisplayName, e.JobTitle)
+}
+
+// GoString makes %#v redact the employee in the same way as String
+func (e Employee) GoString() string {
+	return e.String()
+}
+
+// FullString returns every field of the employee that is set, including personal details, so take care where
+// the result ends up.
+func (e Employee) FullString() string {
+	parts := []string{fmt.Sprintf("ID: %q", e.ID)}
+	for _, fv := range e.fieldValues() {
+		if fv.set {
+			parts = append(parts, fmt.Sprintf("%s: %q", fv.field, fv.value))
+		}
+	}
+	return "Employee{" + strings.Join(parts, ", ") + "}"
 }
 
 // GetEmployeeDirectory returns a list of employees
+//
+// If BambooHR provided an ETag or Last-Modified header on the previous response, the request is made conditionally
+// and the previous list is returned when the directory has not changed.  BambooHR does not document support for
+// conditional requests, so when neither header is returned every call fetches the full directory.
 func (c *Client) GetEmployeeDirectory(ctx context.Context) ([]Employee, error) {
-	url := fmt.Sprintf("%s/employees/directory", c.BaseURL)
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := c.newDirectoryRequest(ctx)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx) // pass along the context
+	c.directory.mu.Lock()
+	etag, lastModified, cached := c.directory.etag, c.directory.lastModified, c.directory.employees
+	c.directory.mu.Unlock()
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if lastModified != "" {
+		req.Header.Set("If-Modified-Since", lastModified)
+	}
+	res, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotModified {
+		return append([]Employee(nil), cached...), nil
+	}
 	er := EmployeeResponse{}
-	if err := c.makeRequest(req, &er); err != nil {
+	if err := c.decode(res.Body, &er); err != nil {
 		return nil, err
 	}
+	c.directory.mu.Lock()
+	c.directory.etag = res.Header.Get("ETag")
+	c.directory.lastModified = res.Header.Get("Last-Modified")
+	c.directory.employees = append([]Employee(nil), er.Employees...)
+	c.directory.mu.Unlock()
 	return er.Employees, nil
 }
 
+// GetEmployeeDirectoryRaw returns the directory exactly as Bamboo returned it, including any fields Employee
+// doesn't have, which is useful when working out how a company's fields map to Employee.  The request is never
+// made conditionally, so the full directory is always returned.
+func (c *Client) GetEmployeeDirectoryRaw(ctx context.Context) (json.RawMessage, error) {
+	req, err := c.newDirectoryRequest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(b), nil
+}
+
+// newDirectoryRequest creates a request for the employee directory
+func (c *Client) newDirectoryRequest(ctx context.Context) (*http.Request, error) {
+	url := fmt.Sprintf("%s/employees/directory", c.BaseURL)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return req.WithContext(ctx), nil // pass along the context
+}
+
+// GetEmployeeDirectorySorted returns a list of employees sorted by LastName, FirstName and then ID.
+// Bamboo doesn't guarantee the order of the directory, so the sorting is done here rather than by the API.
+func (c *Client) GetEmployeeDirectorySorted(ctx context.Context) ([]Employee, error) {
+	directory, err := c.GetEmployeeDirectory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(directory, func(i, j int) bool {
+		a, b := directory[i], directory[j]
+		if a.LastName != b.LastName {
+			return a.LastName < b.LastName
+		}
+		if a.FirstName != b.FirstName {
+			return a.FirstName < b.FirstName
+		}
+		return a.ID < b.ID
+	})
+	return directory, nil
+}
+
+// GetEmployeesByLocation returns the employees in the directory at the given location.  Locations are compared
+// ignoring case and extra whitespace, and a location followed by an office code also matches, so "London" matches
+// employees in "London (LDN)" or "London - LDN" as well as "London".
+func (c *Client) GetEmployeesByLocation(ctx context.Context, location string) ([]Employee, error) {
+	location = normalizeName(location)
+	if location == "" {
+		return nil, nil
+	}
+	directory, err := c.GetEmployeeDirectory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var employees []Employee
+	for _, e := range directory {
+		if matchesLocation(normalizeName(e.Location), location) {
+			employees = append(employees, e)
+		}
+	}
+	return employees, nil
+}
+
+// matchesLocation reports whether an employee's location is the given location, optionally followed by an office code
+func matchesLocation(employeeLocation, location string) bool {
+	if !strings.HasPrefix(employeeLocation, location) {
+		return false
+	}
+	rest := employeeLocation[len(location):]
+	return rest == "" || strings.ContainsAny(rest[:1], " (-/,")
+}
+
+// ResolveNamesToIDs looks up employee IDs by name in the directory, returning the IDs keyed by the names given
+// along with the names that couldn't be resolved.  Names are compared ignoring case and extra whitespace, against
+// both the DisplayName and the FirstName followed by the LastName.  A name shared by more than one employee is
+// ambiguous, so it's returned as unresolved rather than guessing.
+func (c *Client) ResolveNamesToIDs(ctx context.Context, names []string) (map[string]string, []string, error) {
+	directory, err := c.GetEmployeeDirectory(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	index := map[string]map[string]bool{}
+	for _, e := range directory {
+		for _, name := range []string{e.DisplayName, e.FirstName + " " + e.LastName} {
+			key := normalizeName(name)
+			if key == "" {
+				continue
+			}
+			if index[key] == nil {
+				index[key] = map[string]bool{}
+			}
+			index[key][e.ID] = true
+		}
+	}
+	resolved := map[string]string{}
+	var unresolved []string
+	for _, name := range names {
+		ids := index[normalizeName(name)]
+		if len(ids) != 1 {
+			unresolved = append(unresolved, name)
+			continue
+		}
+		for id := range ids {
+			resolved[name] = id
+		}
+	}
+	return resolved, unresolved, nil
+}
+
+// normalizeName lower cases a name and collapses any whitespace so names can be compared
+func normalizeName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// DirectoryOptions changes which employees are included by GetEmployeeDirectoryWithOptions
+type DirectoryOptions struct {
+	// Include inactive employees, e.g. those who have left, as well as active ones
+	IncludeInactive bool
+}
+
+// GetEmployeeDirectoryWithOptions returns a list of employees, optionally including inactive employees.
+// The directory only ever contains active employees, so when IncludeInactive is set a custom report of the
+// employee fields is requested instead.  Use the Status field to tell active and inactive employees apart.
+func (c *Client) GetEmployeeDirectoryWithOptions(ctx context.Context, opts DirectoryOptions) ([]Employee, error) {
+	if !opts.IncludeInactive {
+		return c.GetEmployeeDirectory(ctx)
+	}
+	report := struct {
+		Title string `json:"title"`
+		EmployeeResponse
+	}{}
+	if err := c.requestCustomReport(ctx, employeeJSONFields(), &report); err != nil {
+		return nil, err
+	}
+	return report.Employees, nil
+}
+
+// GetEmployeeIDByEmailWithOptions retrieves a specific employee ID by work email, optionally including inactive employees.
+// An empty ID is returned if there is no match.
+func (c *Client) GetEmployeeIDByEmailWithOptions(ctx context.Context, email string, opts DirectoryOptions) (string, error) {
+	return c.findEmployeeID(ctx, opts, func(e Employee) bool {
+		return e.WorkEmail == email
+	})
+}
+
+// findEmployeeID returns the ID of the first employee in the directory that matches, or an empty ID if none do.
+func (c *Client) findEmployeeID(ctx context.Context, opts DirectoryOptions, match func(e Employee) bool) (string, error) {
+	directory, err := c.GetEmployeeDirectoryWithOptions(ctx, opts)
+	if err != nil {
+		return "", err
+	}
+	for i := range directory {
+		// Stop scanning if the caller has given up
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		if match(directory[i]) {
+			return directory[i].ID, nil
+		}
+	}
+	return "", nil
+}
+
 // GetEmployeeIDByEmail retrieves a specific employee ID from the directory of all available employees
 func (c *Client) GetEmployeeIDByEmail(email string) (string, error) {
 	directory, err := c.GetEmployeeDirectory(context.TODO())
@@ -121,50 +530,323 @@ func (c *Client) GetEmployeeIDByEmail(email string) (string, error) {
 
 // GetEmployeeIDByEmail retrieves a specific employee details by email from the directory of all available employees - makes two requests
 func (c *Client) GetEmployeeByEmail(ctx context.Context, email string, fields ...EmployeeField) (Employee, error) {
-	var id string
-	var employee Employee
-	directory, err := c.GetEmployeeDirectory(ctx)
+	return c.GetEmployeeByEmailWithOptions(ctx, email, DirectoryOptions{}, fields...)
+}
+
+// GetEmployeeByEmailWithOptions retrieves a specific employee details by work email, optionally including inactive
+// employees - makes two requests.
+func (c *Client) GetEmployeeByEmailWithOptions(ctx context.Context, email string, opts DirectoryOptions, fields ...EmployeeField) (Employee, error) {
+	id, err := c.findEmployeeID(ctx, opts, func(e Employee) bool {
+		return e.WorkEmail == email
+	})
 	if err != nil {
-		return employee, err
+		return Employee{}, err
 	}
-	for i, _ := range directory {
-		if directory[i].WorkEmail == email {
-			id = directory[i].ID
-			break
-		}
+	if len(id) == 0 {
+		return Employee{}, ErrEmployeeNotFound
 	}
+	return c.GetEmployee(ctx, id, fields...)
+}
+
+// GetEmployeeByAnyEmail retrieves a specific employee details by either their work or home email, ignoring case,
+// from the directory of all available employees - makes two requests.
+// Home emails can only be matched if the company includes them in the directory.
+func (c *Client) GetEmployeeByAnyEmail(ctx context.Context, email string, fields ...EmployeeField) (Employee, error) {
+	return c.GetEmployeeByAnyEmailWithOptions(ctx, email, DirectoryOptions{}, fields...)
+}
 
+// GetEmployeeByAnyEmailWithOptions retrieves a specific employee details by either their work or home email in the
+// same way as GetEmployeeByAnyEmail, optionally including inactive employees.  Including inactive employees uses a
+// report, which always includes home emails.
+func (c *Client) GetEmployeeByAnyEmailWithOptions(ctx context.Context, email string, opts DirectoryOptions, fields ...EmployeeField) (Employee, error) {
+	if email == "" {
+		return Employee{}, ErrEmployeeNotFound
+	}
+	id, err := c.findEmployeeID(ctx, opts, func(e Employee) bool {
+		return strings.EqualFold(e.WorkEmail, email) || strings.EqualFold(e.HomeEmail, email)
+	})
+	if err != nil {
+		return Employee{}, err
+	}
 	if len(id) == 0 {
-		return employee, errors.New("No employee found")
+		return Employee{}, ErrEmployeeNotFound
 	}
-
 	return c.GetEmployee(ctx, id, fields...)
 }
 
 // GetEmployee retrieves a specific employee by ID and allows the caller to specify fields.
-// All fields are returned if none are specified.
+// All fields are returned if none are specified, or the Client's DefaultEmployeeFields if they are set.
+// Long lists of fields are split over several requests, to keep within URL length limits, and the results merged.
 func (c *Client) GetEmployee(ctx context.Context, id string, fields ...EmployeeField) (Employee, error) {
 	var employee Employee
-	url := fmt.Sprintf("%s/employees/%s", c.BaseURL, id)
-	req, err := http.NewRequest("GET", url, nil)
+	reqs, err := c.newGetEmployeeRequests(ctx, id, fields)
 	if err != nil {
 		return employee, err
 	}
+	// Each response only includes its own fields, so decoding them all into the same employee merges them
+	for _, req := range reqs {
+		if err := c.makeRequest(req, &employee); err != nil {
+			return employee, err
+		}
+	}
+	return employee, nil
+}
+
+// GetEmployeeRaw retrieves a specific employee by ID in the same way as GetEmployee, but returns the fields
+// exactly as Bamboo provided them, keyed by the field name.  This is useful for custom fields.
+func (c *Client) GetEmployeeRaw(ctx context.Context, id string, fields ...EmployeeField) (map[string]interface{}, error) {
+	reqs, err := c.newGetEmployeeRequests(ctx, id, fields)
+	if err != nil {
+		return nil, err
+	}
+	employee := map[string]interface{}{}
+	for _, req := range reqs {
+		if err := c.makeRequest(req, &employee); err != nil {
+			return nil, err
+		}
+	}
+	return employee, nil
+}
+
+// GetEmployeeField retrieves a single field for a specific employee by ID, such as a custom field.
+// An empty string is returned if the field isn't set, and ErrEmployeeNotFound if the employee doesn't exist.
+func (c *Client) GetEmployeeField(ctx context.Context, id, alias string) (string, error) {
+	employee, err := c.GetEmployeeRaw(ctx, id, EmployeeField(alias))
+	if isStatus(err, http.StatusNotFound) {
+		return "", ErrEmployeeNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return rawFieldString(lookupField(employee, alias)), nil
+}
+
+// EmployeeExists reports whether an employee exists and, if so, whether they're active, by requesting only their
+// status.  Terminated employees still exist but aren't active.  A missing employee isn't an error.
+func (c *Client) EmployeeExists(ctx context.Context, id string) (exists bool, active bool, err error) {
+	status, err := c.GetEmployeeField(ctx, id, "status")
+	if err == ErrEmployeeNotFound {
+		return false, false, nil
+	}
+	if err != nil {
+		return false, false, err
+	}
+	return true, strings.EqualFold(status, "Active"), nil
+}
+
+// GetEmployeeCustomFields retrieves the given fields, typically custom fields, for a specific employee by ID,
+// keyed by alias.  Fields that aren't set are returned as empty strings.  If MetaCacheTTL is set the aliases are
+// checked against the cached field metadata first, returning an ErrUnknownField for any the company doesn't have.
+func (c *Client) GetEmployeeCustomFields(ctx context.Context, id string, aliases []string) (map[string]string, error) {
+	if err := c.validateFieldAliases(ctx, aliases); err != nil {
+		return nil, err
+	}
+	fields := make([]EmployeeField, 0, len(aliases))
+	for _, alias := range aliases {
+		fields = append(fields, EmployeeField(alias))
+	}
+	employee, err := c.GetEmployeeRaw(ctx, id, fields...)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]string, len(aliases))
+	for _, alias := range aliases {
+		values[alias] = rawFieldString(lookupField(employee, alias))
+	}
+	return values, nil
+}
+
+// SetEmployeeCustomFields updates the given fields, typically custom fields, for a specific employee by ID.
+// The aliases are checked in the same way as GetEmployeeCustomFields.
+func (c *Client) SetEmployeeCustomFields(ctx context.Context, id string, values map[string]string) error {
+	aliases := make([]string, 0, len(values))
+	fields := make(map[EmployeeField]string, len(values))
+	for alias, value := range values {
+		aliases = append(aliases, alias)
+		fields[EmployeeField(alias)] = value
+	}
+	sort.Strings(aliases)
+	if err := c.validateFieldAliases(ctx, aliases); err != nil {
+		return err
+	}
+	return c.UpdateEmployee(ctx, id, fields)
+}
+
+// validateFieldAliases returns an ErrUnknownField for the first alias that isn't in the field metadata, ignoring
+// case.  Fields without an alias are requested by their ID, so IDs are accepted too.  Nothing is checked unless
+// MetaCacheTTL is set, to avoid an extra request each time.
+func (c *Client) validateFieldAliases(ctx context.Context, aliases []string) error {
+	if c.MetaCacheTTL <= 0 {
+		return nil
+	}
+	fields, err := c.GetFields(ctx)
+	if err != nil {
+		return err
+	}
+	known := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		known[strings.ToLower(f.Alias)] = true
+		known[string(f.ID)] = true
+	}
+	for _, alias := range aliases {
+		if alias == "" || !known[strings.ToLower(alias)] {
+			return &ErrUnknownField{Alias: alias}
+		}
+	}
+	return nil
+}
+
+// lookupField returns a field from an employee or a row of a report, matching the alias ignoring case, since
+// Bamboo accepts aliases in any case but returns them in its own
+func lookupField(fields map[string]interface{}, alias string) interface{} {
+	if v, ok := fields[alias]; ok {
+		return v
+	}
+	for k, v := range fields {
+		if strings.EqualFold(k, alias) {
+			return v
+		}
+	}
+	return nil
+}
+
+// rawFieldString converts a field value from GetEmployeeRaw to a string, with null as an empty string
+func rawFieldString(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// maxFieldsQueryLength is the longest list of fields, once encoded, that will be sent in a single request.
+// Longer lists would risk exceeding URL length limits, so they're split over several requests and merged.
+const maxFieldsQueryLength = 1500
+
+// newGetEmployeeRequests builds the requests for a specific employee, using the default fields if none are given.
+// There's usually one request, but more when the fields need splitting to keep within maxFieldsQueryLength.
+func (c *Client) newGetEmployeeRequests(ctx context.Context, id string, fields []EmployeeField) ([]*http.Request, error) {
+	if err := validateEmployeeID(id); err != nil {
+		return nil, err
+	}
 	ef := EmployeeFields{}
 	if len(fields) > 0 {
 		ef = EmployeeFields{}
 		for _, field := range fields {
 			ef = append(ef, field)
 		}
+	} else if len(c.DefaultEmployeeFields) > 0 {
+		ef = c.DefaultEmployeeFields
 	} else {
-		ef = EmployeeFields{DisplayName, FirstName, LastName, PreferredName, Gender, JobTitle, WorkPhone, MobilePhone, WorkEmail, Department, Location, Division, LinkedIn, WorkPhoneExtension, PhotoUploaded, PhotoURL, CanUploadPhoto, HireDate}
+		ef = defaultEmployeeFields
 	}
-	q := req.URL.Query()
-	q.Add("fields", ef.Join(","))
-	req.URL.RawQuery = q.Encode()
+	if len(fields) == 0 && c.IncludeAddressFields {
+		ef = append(append(EmployeeFields{}, ef...), AddressFields...)
+	}
+	var reqs []*http.Request
+	for _, chunk := range splitFields(ef, maxFieldsQueryLength) {
+		url := fmt.Sprintf("%s/employees/%s", c.BaseURL, id)
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			return nil, err
+		}
+		q := req.URL.Query()
+		q.Add("fields", chunk.Join(","))
+		req.URL.RawQuery = q.Encode()
+		reqs = append(reqs, req.WithContext(ctx))
+	}
+	return reqs, nil
+}
+
+// splitFields splits the fields into lists whose query encoded, comma separated length is at most limit.
+// A single field longer than the limit is kept in a list of its own.
+func splitFields(ef EmployeeFields, limit int) []EmployeeFields {
+	var chunks []EmployeeFields
+	var chunk EmployeeFields
+	n := 0
+	for _, f := range ef {
+		l := len(url.QueryEscape(string(f)))
+		if len(chunk) > 0 && n+len(url.QueryEscape(","))+l > limit {
+			chunks = append(chunks, chunk)
+			chunk, n = nil, 0
+		}
+		if len(chunk) > 0 {
+			n += len(url.QueryEscape(","))
+		}
+		chunk = append(chunk, f)
+		n += l
+	}
+	if len(chunk) > 0 || len(chunks) == 0 {
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
+// GetSelf retrieves the employee associated with the credentials in use, using the special "0" ID.
+// For an API key this is the user that created the key; with user-scoped auth it is the signed in user.
+// All fields are returned if none are specified.
+func (c *Client) GetSelf(ctx context.Context, fields ...EmployeeField) (Employee, error) {
+	return c.GetEmployee(ctx, "0", fields...)
+}
+
+// AddEmployee creates a new employee with the given fields, of which FirstName and LastName are required.
+// The ID of the new employee is returned in the WriteResult.
+func (c *Client) AddEmployee(ctx context.Context, fields map[EmployeeField]string) (WriteResult, error) {
+	body, err := json.Marshal(fields)
+	if err != nil {
+		return WriteResult{}, err
+	}
+	url := fmt.Sprintf("%s/employees/", c.BaseURL)
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return WriteResult{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
 	req = req.WithContext(ctx)
-	if err := c.makeRequest(req, &employee); err != nil {
-		return employee, err
+	return c.makeWriteRequest(req)
+}
+
+// UpdateEmployee updates the given fields for a specific employee by ID.
+func (c *Client) UpdateEmployee(ctx context.Context, id string, fields map[EmployeeField]string) error {
+	if err := validateEmployeeID(id); err != nil {
+		return err
 	}
-	return employee, nil
+	body, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s/employees/%s", c.BaseURL, id)
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(ctx)
+	return c.makeRequest(req, nil)
+}
+
+// UpdateEmployees applies UpdateEmployee to many employees concurrently, keyed by employee ID.
+// The returned map only contains entries for the employees that failed to update.
+// The error is only non-nil when the updates could not be started at all, e.g. the context is already done.
+func (c *Client) UpdateEmployees(ctx context.Context, updates map[string]map[EmployeeField]string) (map[string]error, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(updates))
+	for id := range updates {
+		ids = append(ids, id)
+	}
+	errs := forEachConcurrently(ctx, ids, func(id string) error {
+		return c.UpdateEmployee(ctx, id, updates[id])
+	})
+	return errs, nil
+}
+
+// DeleteEmployee deactivates an employee by ID.  Bamboo does not provide a way to delete employees via the API,
+// so this sets the employee's status to Inactive, which removes them from the directory but keeps their record.
+func (c *Client) DeleteEmployee(ctx context.Context, id string) error {
+	return c.UpdateEmployee(ctx, id, map[EmployeeField]string{Status: "Inactive"})
 }
@@ -0,0 +1,575 @@
+package bamboohr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// boolPtr and intPtr return pointers for the optional fields of Employee
+func boolPtr(b bool) *bool { return &b }
+func intPtr(i int) *int    { return &i }
+
+func TestGetEmployeeDirectoryFixture(t *testing.T) {
+	want := []Employee{
+		{
+			ID:                 "123",
+			DisplayName:        "Jo Bloggs",
+			FirstName:          "Joanna",
+			LastName:           "Bloggs",
+			PreferredName:      "Jo",
+			Gender:             "Female",
+			JobTitle:           "Engineering Manager",
+			WorkPhone:          "801-724-6600",
+			MobilePhone:        "801-724-6601",
+			WorkEmail:          "jo@example.com",
+			Department:         "Engineering",
+			Location:           "Lindon, Utah",
+			Division:           "North America",
+			LinkedIn:           "www.linkedin.com/in/jobloggs",
+			WorkPhoneExtension: "1234",
+			SupervisorName:     "Sam Smith",
+			PhotoUploaded:      boolPtr(true),
+			PhotoURL:           "https://images.example.com/photos/123-0-4.jpg",
+			CanUploadPhoto:     intPtr(1),
+		},
+		{
+			ID:             "124",
+			DisplayName:    "Alex Jones",
+			FirstName:      "Alex",
+			LastName:       "Jones",
+			JobTitle:       "Software Engineer",
+			WorkEmail:      "alex@example.com",
+			Department:     "Engineering",
+			Location:       "Lindon, Utah",
+			SupervisorName: "Jo Bloggs",
+			PhotoUploaded:  boolPtr(false),
+			PhotoURL:       "https://images.example.com/photos/initials/AJ-0-4.png",
+			CanUploadPhoto: intPtr(0),
+		},
+	}
+	for _, strict := range []bool{false, true} {
+		c := newTestClient(t, serveFixture(t, "directory.json"))
+		c.StrictJSON = strict
+		employees, err := c.GetEmployeeDirectory(context.Background())
+		if err != nil {
+			t.Fatalf("strict %v: %v", strict, err)
+		}
+		if !reflect.DeepEqual(employees, want) {
+			t.Errorf("strict %v: got %+v, want %+v", strict, employees, want)
+		}
+	}
+}
+
+func TestGetEmployeeFixture(t *testing.T) {
+	want := Employee{
+		ID:               "124",
+		FirstName:        "Alex",
+		LastName:         "Jones",
+		WorkEmail:        "alex@example.com",
+		HomeEmail:        "alex@home.example.com",
+		HireDate:         "2019-03-04",
+		Status:           "Active",
+		MaritalStatus:    MaritalStatusMarried,
+		EmploymentStatus: EmploymentStatusFullTime,
+		Address1:         "335 S 560 W",
+		City:             "Lindon",
+		State:            "UT",
+		Zip:              "84042",
+		Country:          "United States",
+		SupervisorEID:    "123",
+		SupervisorName:   "Bloggs, Jo",
+		PhotoUploaded:    boolPtr(false),
+		CanUploadPhoto:   intPtr(1),
+	}
+	for _, strict := range []bool{false, true} {
+		c := newTestClient(t, serveFixture(t, "employee.json"))
+		c.StrictJSON = strict
+		e, err := c.GetEmployee(context.Background(), "124")
+		if err != nil {
+			t.Fatalf("strict %v: %v", strict, err)
+		}
+		if !reflect.DeepEqual(e, want) {
+			t.Errorf("strict %v: got %+v, want %+v", strict, e, want)
+		}
+	}
+}
+
+func TestGetEmployeeCanUploadPhoto(t *testing.T) {
+	for _, name := range []string{"employee-number-can-upload-photo.json", "employee-string-can-upload-photo.json"} {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t, serveFixture(t, name))
+			c.StrictJSON = true
+			e, err := c.GetEmployee(context.Background(), "123")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if e.CanUploadPhoto == nil || *e.CanUploadPhoto != 1 {
+				t.Errorf("CanUploadPhoto = %v, want 1", e.CanUploadPhoto)
+			}
+		})
+	}
+}
+
+func TestEmailLookupsIncludeInactive(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/employees/directory":
+			w.Write([]byte(`{"fields":[],"employees":[{"id":"1","workEmail":"jo@example.com"}]}`))
+		case "/reports/custom":
+			w.Write([]byte(`{"title":"Report","fields":[],"employees":[` +
+				`{"id":"1","workEmail":"jo@example.com","status":"Active"},` +
+				`{"id":"2","workEmail":"sam@example.com","homeEmail":"sam@home.example.com","status":"Inactive"}]}`))
+		case "/employees/2":
+			w.Write([]byte(`{"id":"2","firstName":"Sam"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	ctx := context.Background()
+	inactive := DirectoryOptions{IncludeInactive: true}
+
+	if _, err := c.GetEmployeeByEmail(ctx, "sam@example.com"); err != ErrEmployeeNotFound {
+		t.Errorf("GetEmployeeByEmail err = %v, want ErrEmployeeNotFound", err)
+	}
+	if _, err := c.GetEmployeeByAnyEmail(ctx, "sam@home.example.com"); err != ErrEmployeeNotFound {
+		t.Errorf("GetEmployeeByAnyEmail err = %v, want ErrEmployeeNotFound", err)
+	}
+	if id, err := c.GetEmployeeIDByEmailWithOptions(ctx, "sam@example.com", inactive); err != nil || id != "2" {
+		t.Errorf("GetEmployeeIDByEmailWithOptions = %q, %v, want 2", id, err)
+	}
+	if e, err := c.GetEmployeeByEmailWithOptions(ctx, "sam@example.com", inactive); err != nil || e.ID != "2" {
+		t.Errorf("GetEmployeeByEmailWithOptions = %q, %v, want 2", e.ID, err)
+	}
+	if e, err := c.GetEmployeeByAnyEmailWithOptions(ctx, "SAM@home.example.com", inactive); err != nil || e.ID != "2" {
+		t.Errorf("GetEmployeeByAnyEmailWithOptions = %q, %v, want 2", e.ID, err)
+	}
+	if _, err := c.GetEmployeeByEmailWithOptions(ctx, "nobody@example.com", inactive); err != ErrEmployeeNotFound {
+		t.Errorf("GetEmployeeByEmailWithOptions err = %v, want ErrEmployeeNotFound", err)
+	}
+}
+
+func TestCustomFieldsValidateAliases(t *testing.T) {
+	tests := []struct {
+		name    string
+		ttl     time.Duration
+		aliases []string
+		unknown string
+	}{
+		{name: "known alias", ttl: time.Hour, aliases: []string{"customShirtSize"}},
+		{name: "field ID without alias", ttl: time.Hour, aliases: []string{"4002"}},
+		{name: "unknown alias", ttl: time.Hour, aliases: []string{"customShirtSize", "customShoeSize"}, unknown: "customShoeSize"},
+		{name: "not checked without cache", aliases: []string{"customShoeSize"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requested, updated bool
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.URL.Path == "/meta/fields/":
+					w.Write([]byte(`[{"id":4001,"name":"Shirt Size","type":"list","alias":"customShirtSize"},` +
+						`{"id":"4002","name":"Badge Colour","type":"list"}]`))
+				case r.Method == "GET":
+					requested = true
+					w.Write([]byte(`{"id":"123","customShirtSize":"M"}`))
+				default:
+					updated = true
+				}
+			})
+			c.MetaCacheTTL = tt.ttl
+			ctx := context.Background()
+			values := map[string]string{}
+			for _, alias := range tt.aliases {
+				values[alias] = "L"
+			}
+
+			_, getErr := c.GetEmployeeCustomFields(ctx, "123", tt.aliases)
+			setErr := c.SetEmployeeCustomFields(ctx, "123", values)
+			for _, err := range []error{getErr, setErr} {
+				if tt.unknown == "" {
+					if err != nil {
+						t.Errorf("err = %v, want nil", err)
+					}
+					continue
+				}
+				if e, ok := err.(*ErrUnknownField); !ok || e.Alias != tt.unknown {
+					t.Errorf("err = %v, want ErrUnknownField for %q", err, tt.unknown)
+				}
+			}
+			if want := tt.unknown == ""; requested != want || updated != want {
+				t.Errorf("requested = %v, updated = %v, want %v", requested, updated, want)
+			}
+		})
+	}
+}
+
+func TestEmployeeJSONTags(t *testing.T) {
+	// Every field gets a distinct value under its camelCase key, so a missing or wrong tag leaves a field unset
+	payload := map[string]interface{}{}
+	for i, name := range employeeJSONFields() {
+		switch name {
+		case "photoUploaded":
+			payload[name] = true
+		case "canUploadPhoto":
+			payload[name] = 1
+		default:
+			payload[name] = fmt.Sprintf("value%d", i)
+		}
+	}
+	b, err := json.Marshal(map[string]interface{}{"fields": []interface{}{}, "employees": []interface{}{payload}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write(b)
+	})
+	c.StrictJSON = true
+	employees, err := c.GetEmployeeDirectory(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(employees) != 1 {
+		t.Fatalf("got %d employees, want 1", len(employees))
+	}
+	v := reflect.ValueOf(employees[0])
+	for i := 0; i < v.NumField(); i++ {
+		if v.Field(i).IsZero() {
+			t.Errorf("%s not decoded from %q", v.Type().Field(i).Name, v.Type().Field(i).Tag.Get("json"))
+		}
+	}
+	if want := "value0"; employees[0].ID != want {
+		t.Errorf("ID = %q, want %q", employees[0].ID, want)
+	}
+}
+
+func TestFindEmployeeIDCancelled(t *testing.T) {
+	var b strings.Builder
+	b.WriteString(`{"fields":[],"employees":[`)
+	for i := 1; i <= 10000; i++ {
+		if i > 1 {
+			b.WriteString(",")
+		}
+		fmt.Fprintf(&b, `{"id":"%d","workEmail":"employee%d@example.com"}`, i, i)
+	}
+	b.WriteString(`]}`)
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(b.String()))
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	scanned := 0
+	id, err := c.findEmployeeID(ctx, DirectoryOptions{}, func(e Employee) bool {
+		scanned++
+		if scanned == 100 {
+			cancel()
+		}
+		return e.WorkEmail == "employee10000@example.com"
+	})
+	if err != context.Canceled || id != "" {
+		t.Errorf("findEmployeeID = %q, %v, want context.Canceled", id, err)
+	}
+	if scanned != 100 {
+		t.Errorf("scanned %d employees after cancelling, want 100", scanned)
+	}
+
+	if _, err := c.GetEmployeeByEmail(ctx, "employee1@example.com"); err == nil {
+		t.Error("GetEmployeeByEmail with a cancelled context succeeded")
+	}
+}
+
+func TestForEachConcurrentlyCancelled(t *testing.T) {
+	ids := make([]string, 50)
+	for i := range ids {
+		ids[i] = fmt.Sprint(i)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var mu sync.Mutex
+	calls := 0
+	errs := forEachConcurrently(ctx, ids, func(id string) error {
+		mu.Lock()
+		calls++
+		if calls == 1 {
+			cancel()
+		}
+		mu.Unlock()
+		return nil
+	})
+	if calls >= len(ids) {
+		t.Fatalf("all %d calls made after cancelling", calls)
+	}
+	if len(errs) != len(ids)-calls {
+		t.Errorf("got %d errors, want one for each of the %d IDs not started", len(errs), len(ids)-calls)
+	}
+	for id, err := range errs {
+		if err != context.Canceled {
+			t.Errorf("error for %s = %v, want context.Canceled", id, err)
+		}
+	}
+}
+
+func TestPreferredDisplayName(t *testing.T) {
+	tests := []struct {
+		name string
+		e    Employee
+		want string
+	}{
+		{name: "preferred name wins", e: Employee{PreferredName: "Jo", FirstName: "Joanna", LastName: "Bloggs", DisplayName: "Joanna Bloggs"}, want: "Jo Bloggs"},
+		{name: "first name without preferred", e: Employee{FirstName: "Joanna", LastName: "Bloggs", DisplayName: "JB"}, want: "Joanna Bloggs"},
+		{name: "blank preferred ignored", e: Employee{PreferredName: "  ", FirstName: "Joanna", LastName: "Bloggs"}, want: "Joanna Bloggs"},
+		{name: "spaces trimmed", e: Employee{PreferredName: " Jo ", LastName: " Bloggs "}, want: "Jo Bloggs"},
+		{name: "first name only", e: Employee{FirstName: "Joanna"}, want: "Joanna"},
+		{name: "last name only", e: Employee{LastName: "Bloggs"}, want: "Bloggs"},
+		{name: "display name when no names", e: Employee{DisplayName: "Jo Bloggs"}, want: "Jo Bloggs"},
+		{name: "display name when names blank", e: Employee{FirstName: " ", LastName: " ", DisplayName: "Jo Bloggs"}, want: "Jo Bloggs"},
+		{name: "all empty", e: Employee{}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.PreferredDisplayName(); got != tt.want {
+				t.Errorf("PreferredDisplayName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTenure(t *testing.T) {
+	day := 24 * time.Hour
+	est := time.FixedZone("EST", -5*60*60)
+	tests := []struct {
+		name   string
+		hired  string
+		asOf   time.Time
+		tenure time.Duration
+		years  float64
+	}{
+		{name: "unset", asOf: date(t, "2024-01-01")},
+		{name: "invalid", hired: "0000-00-00", asOf: date(t, "2024-01-01")},
+		{name: "future hire", hired: "2024-06-01", asOf: date(t, "2024-01-01")},
+		{name: "hire date", hired: "2024-01-01", asOf: date(t, "2024-01-01")},
+		{name: "part of the day", hired: "2024-01-01", asOf: date(t, "2024-01-01").Add(12 * time.Hour), tenure: 12 * time.Hour, years: 0.5 / 366},
+		{name: "asOf location", hired: "2024-01-01", asOf: time.Date(2024, 1, 2, 0, 0, 0, 0, est), tenure: day, years: 1.0 / 366},
+		{name: "year over a leap day", hired: "2020-01-15", asOf: date(t, "2021-01-15"), tenure: 366 * day, years: 1},
+		{name: "year without a leap day", hired: "2021-01-15", asOf: date(t, "2022-01-15"), tenure: 365 * day, years: 1},
+		{name: "leap day hire before anniversary", hired: "2020-02-29", asOf: date(t, "2021-02-28"), tenure: 365 * day, years: 365.0 / 366},
+		{name: "leap day hire on 1st of March", hired: "2020-02-29", asOf: date(t, "2021-03-01"), tenure: 366 * day, years: 1},
+		{name: "leap day hire in leap year", hired: "2020-02-29", asOf: date(t, "2024-02-29"), tenure: 1461 * day, years: 4},
+		{name: "mid-month hire half way", hired: "2023-06-15", asOf: date(t, "2023-12-15"), tenure: 183 * day, years: 183.0 / 366},
+		{name: "mid-month hire after anniversary", hired: "2023-06-15", asOf: date(t, "2025-07-15"), tenure: 761 * day, years: 2 + 30.0/365},
+		{name: "mid-month hire before anniversary", hired: "2023-06-15", asOf: date(t, "2025-06-14"), tenure: 730 * day, years: 1 + 364.0/365},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := Employee{HireDate: tt.hired}
+			if got := e.Tenure(tt.asOf); got != tt.tenure {
+				t.Errorf("Tenure = %v, want %v", got, tt.tenure)
+			}
+			if got := e.TenureYears(tt.asOf); math.Abs(got-tt.years) > 1e-9 {
+				t.Errorf("TenureYears = %v, want %v", got, tt.years)
+			}
+		})
+	}
+}
+
+func TestGetEmployeesByLocation(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"fields":[],"employees":[` +
+			`{"id":"1","location":"London"},` +
+			`{"id":"2","location":"London (LDN)"},` +
+			`{"id":"3","location":"  london   - LDN "},` +
+			`{"id":"4","location":"Londonderry"},` +
+			`{"id":"5","location":"New York, NY"},` +
+			`{"id":"6","location":"New York/NYC"},` +
+			`{"id":"7","location":"York"},` +
+			`{"id":"8","location":null}]}`))
+	})
+	tests := []struct {
+		location string
+		want     string
+	}{
+		{location: "London", want: "[1 2 3]"},
+		{location: " LONDON ", want: "[1 2 3]"},
+		{location: "London (LDN)", want: "[2]"},
+		{location: "Londonderry", want: "[4]"},
+		{location: "new  york", want: "[5 6]"},
+		{location: "York", want: "[7]"},
+		{location: "Paris", want: "[]"},
+		{location: " ", want: "[]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.location, func(t *testing.T) {
+			employees, err := c.GetEmployeesByLocation(context.Background(), tt.location)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ids := []string{}
+			for _, e := range employees {
+				ids = append(ids, e.ID)
+			}
+			if got := fmt.Sprint(ids); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEmployeeExists(t *testing.T) {
+	var fields []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fields = append(fields, r.URL.Query().Get("fields"))
+		switch r.URL.Path {
+		case "/employees/1":
+			w.Write([]byte(`{"id":"1","status":"Active"}`))
+		case "/employees/2":
+			w.Write([]byte(`{"id":"2","status":"Inactive"}`))
+		case "/employees/3":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+	tests := []struct {
+		id             string
+		exists, active bool
+		fails          bool
+	}{
+		{id: "1", exists: true, active: true},
+		{id: "2", exists: true},
+		{id: "3"},
+		{id: "4", fails: true},
+		{id: "x", fails: true},
+	}
+	for _, tt := range tests {
+		exists, active, err := c.EmployeeExists(context.Background(), tt.id)
+		if (err != nil) != tt.fails || exists != tt.exists || active != tt.active {
+			t.Errorf("EmployeeExists(%s) = %v, %v, %v, want %v, %v, error %v", tt.id, exists, active, err, tt.exists, tt.active, tt.fails)
+		}
+	}
+	for _, f := range fields {
+		if f != "status" {
+			t.Errorf("requested fields %q, want only status", f)
+		}
+	}
+}
+
+func TestGetEmployeeFieldIgnoresCase(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/meta/fields/" {
+			w.Write([]byte(`[{"id":4001,"name":"Shirt Size","type":"list","alias":"customShirtSize"}]`))
+			return
+		}
+		w.Write([]byte(`{"id":"123","status":"Active","customShirtSize":"M"}`))
+	})
+	c.MetaCacheTTL = time.Hour
+	ctx := context.Background()
+	if status, err := c.GetEmployeeField(ctx, "123", string(Status)); err != nil || status != "Active" {
+		t.Errorf("GetEmployeeField(Status) = %q, %v, want Active", status, err)
+	}
+	values, err := c.GetEmployeeCustomFields(ctx, "123", []string{"CustomShirtSize"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values["CustomShirtSize"] != "M" {
+		t.Errorf("GetEmployeeCustomFields = %v, want CustomShirtSize M", values)
+	}
+}
+
+// echoFields returns a handler that responds to each request for an employee with every requested field, recording
+// the fields query of each request
+func echoFields(t *testing.T, queries *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		fields := r.URL.Query().Get("fields")
+		*queries = append(*queries, fields)
+		employee := map[string]string{"id": "123"}
+		for _, f := range strings.Split(fields, ",") {
+			employee[f] = f + " value"
+		}
+		b, err := json.Marshal(employee)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		w.Write(b)
+	}
+}
+
+func TestGetEmployeeSplitsLargeFieldLists(t *testing.T) {
+	fields := []EmployeeField{"firstName"}
+	for i := 0; i < 300; i++ {
+		fields = append(fields, EmployeeField(fmt.Sprintf("customField%d", i)))
+	}
+	fields = append(fields, "workEmail")
+
+	checkQueries := func(t *testing.T, queries []string) {
+		t.Helper()
+		if len(queries) < 2 {
+			t.Errorf("made %d requests, want the fields split over several", len(queries))
+		}
+		requested := 0
+		for _, q := range queries {
+			if l := len(url.QueryEscape(q)); l > maxFieldsQueryLength {
+				t.Errorf("fields query is %d long, want at most %d", l, maxFieldsQueryLength)
+			}
+			requested += len(strings.Split(q, ","))
+		}
+		if requested != len(fields) {
+			t.Errorf("requested %d fields, want %d", requested, len(fields))
+		}
+	}
+
+	t.Run("GetEmployeeRaw", func(t *testing.T) {
+		var queries []string
+		c := newTestClient(t, echoFields(t, &queries))
+		employee, err := c.GetEmployeeRaw(context.Background(), "123", fields...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkQueries(t, queries)
+		if len(employee) != len(fields)+1 {
+			t.Errorf("got %d fields, want %d and the id", len(employee), len(fields))
+		}
+		for _, f := range fields {
+			if employee[string(f)] != string(f)+" value" {
+				t.Errorf("%s = %v, want it merged into the result", f, employee[string(f)])
+			}
+		}
+	})
+
+	t.Run("GetEmployee", func(t *testing.T) {
+		var queries []string
+		c := newTestClient(t, echoFields(t, &queries))
+		e, err := c.GetEmployee(context.Background(), "123", fields...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkQueries(t, queries)
+		if e.ID != "123" || e.FirstName != "firstName value" || e.WorkEmail != "workEmail value" {
+			t.Errorf("got %+v, want fields from the first and last requests merged", e)
+		}
+	})
+
+	t.Run("field longer than the limit", func(t *testing.T) {
+		long := EmployeeField("custom" + strings.Repeat("x", maxFieldsQueryLength))
+		var queries []string
+		c := newTestClient(t, echoFields(t, &queries))
+		employee, err := c.GetEmployeeRaw(context.Background(), "123", "firstName", long, "workEmail")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []string{"firstName", string(long), "workEmail"}; !reflect.DeepEqual(queries, want) {
+			t.Errorf("requested %d lists of fields, want the long field on its own", len(queries))
+		}
+		if len(employee) != 4 || employee[string(long)] != string(long)+" value" {
+			t.Errorf("got %d fields, want all three and the id", len(employee))
+		}
+	})
+}
@@ -0,0 +1,144 @@
+package bamboohr
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"gopkg.in/errgo.v2/errors"
+)
+
+// ErrEmployeeNotFound is returned when the requested employee doesn't exist
+var ErrEmployeeNotFound = errors.New("No employee found")
+
+// ErrResponseTooLarge is returned when a response is larger than the Client's MaxResponseSize
+var ErrResponseTooLarge = errors.New("response too large")
+
+// ErrNoJobInfo is returned when an employee has no current job information
+var ErrNoJobInfo = errors.New("no current job information")
+
+// ErrNoPhoto is returned by GetEmployeePhotos for employees it skipped because they don't have a photo
+var ErrNoPhoto = errors.New("employee has no photo")
+
+// ErrNoManager is returned when an employee is at the top of the reporting chain
+var ErrNoManager = errors.New("employee has no manager")
+
+// APIError is returned when Bamboo responds with an error status code
+type APIError struct {
+	StatusCode int
+	// Message is the reason Bamboo gave for the error, if any
+	Message string
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("error from bamboo, status code: %d", e.StatusCode)
+	}
+	return fmt.Sprintf("error from bamboo, status code: %d: %s", e.StatusCode, e.Message)
+}
+
+// ErrFeatureNotEnabled is returned when Bamboo refuses a request because the company doesn't have the add-on
+// the endpoint belongs to, e.g. time tracking.  Unlike other 403 responses it doesn't mean the credentials are bad.
+type ErrFeatureNotEnabled struct {
+	*APIError
+	// Feature is the name of the add-on if Bamboo included it in the message
+	Feature string
+}
+
+func (e *ErrFeatureNotEnabled) Error() string {
+	if e.Feature == "" {
+		return fmt.Sprintf("feature not enabled: %s", e.APIError)
+	}
+	return fmt.Sprintf("feature not enabled: %s: %s", e.Feature, e.APIError)
+}
+
+// Unwrap returns the underlying APIError
+func (e *ErrFeatureNotEnabled) Unwrap() error {
+	return e.APIError
+}
+
+var (
+	// featureNotEnabledMessage matches the messages Bamboo uses when a company lacks an add-on
+	featureNotEnabledMessage = regexp.MustCompile(`(?i)upgrade|add-?on|not enabled|not turned on`)
+	// featureName picks the feature out of messages such as "Time Tracking is not enabled for this company"
+	featureName = regexp.MustCompile(`(?i)^(?:the )?(.+?) (?:is not enabled|is not turned on|requires an? )`)
+)
+
+// newAPIError builds the error for a response with an error status code.  Bamboo gives the reason in a header,
+// falling back to the start of the body when there isn't one.
+func newAPIError(res *http.Response) error {
+	e := &APIError{
+		StatusCode: res.StatusCode,
+		Message:    res.Header.Get("X-BambooHR-Error-Message"),
+	}
+	if e.Message == "" {
+		b, _ := ioutil.ReadAll(io.LimitReader(res.Body, 1024))
+		e.Message = strings.TrimSpace(string(b))
+	}
+	if res.StatusCode == http.StatusForbidden && featureNotEnabledMessage.MatchString(e.Message) {
+		fe := &ErrFeatureNotEnabled{APIError: e}
+		if m := featureName.FindStringSubmatch(e.Message); m != nil {
+			fe.Feature = m[1]
+		}
+		return fe
+	}
+	return e
+}
+
+// ErrInvalidEmployeeID is returned when an employee ID can't be valid, before any request is made.
+// IDs must be numeric, or the special "0" or "self" for the employee associated with the credentials.
+type ErrInvalidEmployeeID struct {
+	ID string
+}
+
+func (e *ErrInvalidEmployeeID) Error() string {
+	return fmt.Sprintf("invalid employee ID: %q", e.ID)
+}
+
+// validateEmployeeID returns an ErrInvalidEmployeeID if the ID isn't numeric or "self"
+func validateEmployeeID(id string) error {
+	if id == "self" {
+		return nil
+	}
+	if id == "" {
+		return &ErrInvalidEmployeeID{ID: id}
+	}
+	for _, r := range id {
+		if r < '0' || r > '9' {
+			return &ErrInvalidEmployeeID{ID: id}
+		}
+	}
+	return nil
+}
+
+// isStatus reports whether err is an error response from Bamboo with the given status code
+func isStatus(err error, statusCode int) bool {
+	switch e := err.(type) {
+	case *APIError:
+		return e.StatusCode == statusCode
+	case *ErrFeatureNotEnabled:
+		return e.StatusCode == statusCode
+	}
+	return false
+}
+
+// ErrUnknownField is returned when a field alias isn't in the company's field metadata
+type ErrUnknownField struct {
+	Alias string
+}
+
+func (e *ErrUnknownField) Error() string {
+	return fmt.Sprintf("unknown field: %q", e.Alias)
+}
+
+// ErrUnknownTimeZone is returned when an employee's location can't be mapped to a time zone
+type ErrUnknownTimeZone struct {
+	Location string
+}
+
+func (e *ErrUnknownTimeZone) Error() string {
+	return fmt.Sprintf("no time zone for location: %q", e.Location)
+}
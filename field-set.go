@@ -0,0 +1,154 @@
+package bamboohr
+
+// FieldSetBuilder builds a list of EmployeeFields for GetEmployee, ignoring any fields that are added more than once.
+type FieldSetBuilder struct {
+	fields EmployeeFields
+	seen   map[EmployeeField]bool
+}
+
+// FieldSet returns a new, empty FieldSetBuilder
+func FieldSet() *FieldSetBuilder {
+	return &FieldSetBuilder{seen: map[EmployeeField]bool{}}
+}
+
+// With adds the given fields, which is useful for custom fields without their own method
+func (b *FieldSetBuilder) With(fields ...EmployeeField) *FieldSetBuilder {
+	for _, f := range fields {
+		if b.seen[f] {
+			continue
+		}
+		b.seen[f] = true
+		b.fields = append(b.fields, f)
+	}
+	return b
+}
+
+// Build returns the fields in the order they were first added
+func (b *FieldSetBuilder) Build() EmployeeFields {
+	return append(EmployeeFields(nil), b.fields...)
+}
+
+// WithDisplayName adds the DisplayName field
+func (b *FieldSetBuilder) WithDisplayName() *FieldSetBuilder {
+	return b.With(DisplayName)
+}
+
+// WithFirstName adds the FirstName field
+func (b *FieldSetBuilder) WithFirstName() *FieldSetBuilder {
+	return b.With(FirstName)
+}
+
+// WithLastName adds the LastName field
+func (b *FieldSetBuilder) WithLastName() *FieldSetBuilder {
+	return b.With(LastName)
+}
+
+// WithPreferredName adds the PreferredName field
+func (b *FieldSetBuilder) WithPreferredName() *FieldSetBuilder {
+	return b.With(PreferredName)
+}
+
+// WithGender adds the Gender field
+func (b *FieldSetBuilder) WithGender() *FieldSetBuilder {
+	return b.With(Gender)
+}
+
+// WithJobTitle adds the JobTitle field
+func (b *FieldSetBuilder) WithJobTitle() *FieldSetBuilder {
+	return b.With(JobTitle)
+}
+
+// WithWorkPhone adds the WorkPhone field
+func (b *FieldSetBuilder) WithWorkPhone() *FieldSetBuilder {
+	return b.With(WorkPhone)
+}
+
+// WithMobilePhone adds the MobilePhone field
+func (b *FieldSetBuilder) WithMobilePhone() *FieldSetBuilder {
+	return b.With(MobilePhone)
+}
+
+// WithWorkEmail adds the WorkEmail field
+func (b *FieldSetBuilder) WithWorkEmail() *FieldSetBuilder {
+	return b.With(WorkEmail)
+}
+
+// WithHomeEmail adds the HomeEmail field
+func (b *FieldSetBuilder) WithHomeEmail() *FieldSetBuilder {
+	return b.With(HomeEmail)
+}
+
+// WithDepartment adds the Department field
+func (b *FieldSetBuilder) WithDepartment() *FieldSetBuilder {
+	return b.With(Department)
+}
+
+// WithLocation adds the Location field
+func (b *FieldSetBuilder) WithLocation() *FieldSetBuilder {
+	return b.With(Location)
+}
+
+// WithDivision adds the Division field
+func (b *FieldSetBuilder) WithDivision() *FieldSetBuilder {
+	return b.With(Division)
+}
+
+// WithLinkedIn adds the LinkedIn field
+func (b *FieldSetBuilder) WithLinkedIn() *FieldSetBuilder {
+	return b.With(LinkedIn)
+}
+
+// WithWorkPhoneExtension adds the WorkPhoneExtension field
+func (b *FieldSetBuilder) WithWorkPhoneExtension() *FieldSetBuilder {
+	return b.With(WorkPhoneExtension)
+}
+
+// WithPhotoUploaded adds the PhotoUploaded field
+func (b *FieldSetBuilder) WithPhotoUploaded() *FieldSetBuilder {
+	return b.With(PhotoUploaded)
+}
+
+// WithPhotoURL adds the PhotoURL field
+func (b *FieldSetBuilder) WithPhotoURL() *FieldSetBuilder {
+	return b.With(PhotoURL)
+}
+
+// WithCanUploadPhoto adds the CanUploadPhoto field
+func (b *FieldSetBuilder) WithCanUploadPhoto() *FieldSetBuilder {
+	return b.With(CanUploadPhoto)
+}
+
+// WithHireDate adds the HireDate field
+func (b *FieldSetBuilder) WithHireDate() *FieldSetBuilder {
+	return b.With(HireDate)
+}
+
+// WithReportingTo adds the ReportingTo field
+func (b *FieldSetBuilder) WithReportingTo() *FieldSetBuilder {
+	return b.With(ReportingTo)
+}
+
+// WithMaritalStatus adds the MaritalStatus field
+func (b *FieldSetBuilder) WithMaritalStatus() *FieldSetBuilder {
+	return b.With(MaritalStatusField)
+}
+
+// WithEmploymentStatus adds the EmploymentStatus field
+func (b *FieldSetBuilder) WithEmploymentStatus() *FieldSetBuilder {
+	return b.With(EmploymentStatusField)
+}
+
+// WithStatus adds the Status field
+func (b *FieldSetBuilder) WithStatus() *FieldSetBuilder {
+	return b.With(Status)
+}
+
+// WithAddress adds the AddressFields
+func (b *FieldSetBuilder) WithAddress() *FieldSetBuilder {
+	return b.With(AddressFields...)
+}
+
+// WithSupervisor adds the SupervisorEID and Supervisor fields
+func (b *FieldSetBuilder) WithSupervisor() *FieldSetBuilder {
+	return b.With(SupervisorEID, Supervisor)
+}
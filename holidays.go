@@ -0,0 +1,88 @@
+package bamboohr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// Holiday is a company holiday on a single day
+type Holiday struct {
+	ID   int
+	Name string
+	Date time.Time
+}
+
+// whosOutEntry is an entry in the who's out list, either an employee's time off or a company holiday
+type whosOutEntry struct {
+	ID         flexibleInt `json:"id"`
+	Type       string      `json:"type"`
+	EmployeeID flexibleInt `json:"employeeId"` // only for time off
+	Name       string      `json:"name"`
+	Start      string      `json:"start"`
+	End        string      `json:"end"`
+}
+
+// GetCompanyHolidays returns the company holidays between start and end, inclusive, ordered by date.
+// Bamboo has no holidays endpoint, so they're taken from the who's out list, which includes holidays alongside time
+// off.  Holidays lasting more than one day are returned once for each day within the range.
+func (c *Client) GetCompanyHolidays(ctx context.Context, start, end time.Time) ([]Holiday, error) {
+	url := fmt.Sprintf("%s/time_off/whos_out/", c.BaseURL)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	q := req.URL.Query()
+	q.Add("start", start.Format("2006-01-02"))
+	q.Add("end", end.Format("2006-01-02"))
+	req.URL.RawQuery = q.Encode()
+	req = req.WithContext(ctx)
+	entries := []whosOutEntry{}
+	if err := c.makeRequest(req, &entries); err != nil {
+		return nil, err
+	}
+	from, to := start.Format("2006-01-02"), end.Format("2006-01-02")
+	holidays := []Holiday{}
+	for _, e := range entries {
+		if e.Type != "holiday" {
+			continue
+		}
+		first, err := time.Parse("2006-01-02", e.Start)
+		if err != nil {
+			return nil, err
+		}
+		last, err := time.Parse("2006-01-02", e.End)
+		if err != nil {
+			return nil, err
+		}
+		for d := first; !d.After(last); d = d.AddDate(0, 0, 1) {
+			if day := d.Format("2006-01-02"); day >= from && day <= to {
+				holidays = append(holidays, Holiday{ID: e.ID.orZero(), Name: e.Name, Date: d})
+			}
+		}
+	}
+	sort.SliceStable(holidays, func(i, j int) bool { return holidays[i].Date.Before(holidays[j].Date) })
+	return holidays, nil
+}
+
+// WorkingDays returns the number of days from start to end, inclusive, that are neither weekends nor holidays.
+// Only the dates are used, not the times, so a range starting and ending on the same working day counts as one.
+// Holidays on a weekend aren't excluded twice, and zero is returned when end is before start.
+func WorkingDays(start, end time.Time, holidays []Holiday) int {
+	closed := make(map[string]bool, len(holidays))
+	for _, h := range holidays {
+		closed[h.Date.Format("2006-01-02")] = true
+	}
+	first := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	last := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
+	days := 0
+	for d := first; !d.After(last); d = d.AddDate(0, 0, 1) {
+		if d.Weekday() == time.Saturday || d.Weekday() == time.Sunday || closed[d.Format("2006-01-02")] {
+			continue
+		}
+		days++
+	}
+	return days
+}
@@ -0,0 +1,71 @@
+package bamboohr
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// date returns midnight UTC on the given day, e.g. "2024-12-25"
+func date(t *testing.T, day string) time.Time {
+	t.Helper()
+	d, err := time.Parse("2006-01-02", day)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestGetCompanyHolidaysNumbers(t *testing.T) {
+	want := []Holiday{{ID: 7, Name: "Christmas Day", Date: date(t, "2024-12-25")}}
+	for _, name := range []string{"whos-out-number-ids.json", "whos-out-string-ids.json"} {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t, serveFixture(t, name))
+			c.StrictJSON = true
+			holidays, err := c.GetCompanyHolidays(context.Background(), date(t, "2024-12-01"), date(t, "2024-12-31"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(holidays, want) {
+				t.Errorf("got %+v, want %+v", holidays, want)
+			}
+		})
+	}
+}
+
+func TestWorkingDays(t *testing.T) {
+	christmas := []Holiday{
+		{Name: "Christmas Day", Date: date(t, "2024-12-25")},
+		{Name: "Boxing Day", Date: date(t, "2024-12-26")},
+	}
+	// Christmas Day 2022 was a Sunday, observed on the Monday
+	sunday := []Holiday{{Name: "Christmas Day", Date: date(t, "2022-12-25")}}
+	observed := append(sunday, Holiday{Name: "Christmas Day (observed)", Date: date(t, "2022-12-26")})
+	aest := time.FixedZone("AEST", 10*60*60)
+	tests := []struct {
+		name       string
+		start, end time.Time
+		holidays   []Holiday
+		want       int
+	}{
+		{name: "week without holidays", start: date(t, "2024-12-16"), end: date(t, "2024-12-22"), want: 5},
+		{name: "week with holidays", start: date(t, "2024-12-23"), end: date(t, "2024-12-29"), holidays: christmas, want: 3},
+		{name: "holidays outside the range", start: date(t, "2024-12-16"), end: date(t, "2024-12-20"), holidays: christmas, want: 5},
+		{name: "holiday on a weekend", start: date(t, "2022-12-23"), end: date(t, "2022-12-27"), holidays: sunday, want: 3},
+		{name: "holiday on a weekend observed", start: date(t, "2022-12-23"), end: date(t, "2022-12-27"), holidays: observed, want: 2},
+		{name: "single working day", start: date(t, "2024-12-23"), end: date(t, "2024-12-23"), want: 1},
+		{name: "single day on a weekend", start: date(t, "2024-12-21"), end: date(t, "2024-12-21"), want: 0},
+		{name: "single day holiday", start: date(t, "2024-12-25"), end: date(t, "2024-12-25"), holidays: christmas, want: 0},
+		{name: "single day with times", start: date(t, "2024-12-23").Add(9 * time.Hour), end: date(t, "2024-12-23").Add(17 * time.Hour), want: 1},
+		{name: "dates in their own location", start: time.Date(2024, 12, 23, 23, 30, 0, 0, aest), end: time.Date(2024, 12, 24, 1, 0, 0, 0, aest), want: 2},
+		{name: "end before start", start: date(t, "2024-12-24"), end: date(t, "2024-12-23"), want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WorkingDays(tt.start, tt.end, tt.holidays); got != tt.want {
+				t.Errorf("WorkingDays = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,280 @@
+package bamboohr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// FieldID is the ID of a field in the metadata.  Bamboo returns these inconsistently, sometimes as a number
+// and sometimes as a string, so both are accepted and held as a string.
+type FieldID string
+
+// UnmarshalJSON accepts the ID as either a JSON string or number
+func (id *FieldID) UnmarshalJSON(b []byte) error {
+	if len(b) > 0 && b[0] == '"' {
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		*id = FieldID(s)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(b, &n); err != nil {
+		return err
+	}
+	*id = FieldID(n)
+	return nil
+}
+
+// TableMeta describes a table that can be requested for an employee, such as jobInfo or compensation
+type TableMeta struct {
+	Alias  string           `json:"alias"`
+	Fields []TableFieldMeta `json:"fields"`
+}
+
+// TableFieldMeta describes a column of a table
+type TableFieldMeta struct {
+	ID    FieldID `json:"id"`
+	Name  string  `json:"name"`
+	Alias string  `json:"alias"`
+	Type  string  `json:"type"`
+}
+
+// GetTables returns the tables available for the company along with their fields.  If MetaCacheTTL is set the
+// tables are cached for that long.
+func (c *Client) GetTables(ctx context.Context) ([]TableMeta, error) {
+	c.meta.mu.Lock()
+	tables, ok := c.meta.tables, c.meta.fresh(c.meta.tablesAt, c.MetaCacheTTL)
+	c.meta.mu.Unlock()
+	if ok {
+		return copyTables(tables), nil
+	}
+	tables, err := c.getTables(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if c.MetaCacheTTL > 0 {
+		c.meta.mu.Lock()
+		c.meta.tables, c.meta.tablesAt = copyTables(tables), time.Now()
+		c.meta.mu.Unlock()
+	}
+	return tables, nil
+}
+
+// getTables requests the tables, bypassing the cache
+func (c *Client) getTables(ctx context.Context) ([]TableMeta, error) {
+	url := fmt.Sprintf("%s/meta/tables/", c.BaseURL)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	tables := []TableMeta{}
+	if err := c.makeRequest(req, &tables); err != nil {
+		return nil, err
+	}
+	return tables, nil
+}
+
+// FieldMeta describes a field that can be requested for an employee
+type FieldMeta struct {
+	ID    FieldID `json:"id"`
+	Name  string  `json:"name"`
+	Type  string  `json:"type"`
+	Alias string  `json:"alias"`
+}
+
+// GetFields returns the fields available for the company, including custom fields.  If MetaCacheTTL is set the
+// fields are cached for that long.
+func (c *Client) GetFields(ctx context.Context) ([]FieldMeta, error) {
+	c.meta.mu.Lock()
+	fields, ok := c.meta.fields, c.meta.fresh(c.meta.fieldsAt, c.MetaCacheTTL)
+	c.meta.mu.Unlock()
+	if ok {
+		return append([]FieldMeta(nil), fields...), nil
+	}
+	fields, err := c.getFields(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if c.MetaCacheTTL > 0 {
+		c.meta.mu.Lock()
+		c.meta.fields, c.meta.fieldsAt = append([]FieldMeta(nil), fields...), time.Now()
+		c.meta.mu.Unlock()
+	}
+	return fields, nil
+}
+
+// getFields requests the fields, bypassing the cache
+func (c *Client) getFields(ctx context.Context) ([]FieldMeta, error) {
+	url := fmt.Sprintf("%s/meta/fields/", c.BaseURL)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	fields := []FieldMeta{}
+	if err := c.makeRequest(req, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// metaCache holds the last field and table metadata along with when it was fetched.  It is kept separately from
+// the directory cache as metadata changes far less often.
+type metaCache struct {
+	mu       sync.Mutex
+	fields   []FieldMeta
+	fieldsAt time.Time
+	tables   []TableMeta
+	tablesAt time.Time
+}
+
+// fresh reports whether metadata fetched at the given time can still be used
+func (m *metaCache) fresh(at time.Time, ttl time.Duration) bool {
+	return ttl > 0 && !at.IsZero() && time.Since(at) < ttl
+}
+
+// RefreshMetaCache fetches the fields and tables again, replacing any cached metadata, e.g. after custom fields are
+// added.  Nothing is cached unless MetaCacheTTL is set, and the cache is left alone if either request fails.
+func (c *Client) RefreshMetaCache(ctx context.Context) error {
+	fields, err := c.getFields(ctx)
+	if err != nil {
+		return err
+	}
+	tables, err := c.getTables(ctx)
+	if err != nil {
+		return err
+	}
+	if c.MetaCacheTTL <= 0 {
+		return nil
+	}
+	now := time.Now()
+	c.meta.mu.Lock()
+	defer c.meta.mu.Unlock()
+	c.meta.fields, c.meta.fieldsAt = fields, now
+	c.meta.tables, c.meta.tablesAt = tables, now
+	return nil
+}
+
+// copyTables copies the tables and their fields, so the cached metadata can't be changed by callers
+func copyTables(tables []TableMeta) []TableMeta {
+	copied := make([]TableMeta, len(tables))
+	for i, t := range tables {
+		copied[i] = TableMeta{Alias: t.Alias, Fields: append([]TableFieldMeta(nil), t.Fields...)}
+	}
+	return copied
+}
+
+// GetFieldAliasResolver returns a FieldAliasResolver for the company's fields from GetFields, using the cached
+// fields if MetaCacheTTL is set.
+func (c *Client) GetFieldAliasResolver(ctx context.Context) (*FieldAliasResolver, error) {
+	fields, err := c.GetFields(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewFieldAliasResolver(fields), nil
+}
+
+// FieldAliasResolver looks up field aliases by their display name and vice versa
+type FieldAliasResolver struct {
+	nameToAlias map[string]string
+	aliasToName map[string]string
+}
+
+// NewFieldAliasResolver returns a FieldAliasResolver for the given fields, e.g. from GetFields.
+// Fields without an alias are ignored and where names or aliases are repeated the first one wins.
+func NewFieldAliasResolver(fields []FieldMeta) *FieldAliasResolver {
+	r := &FieldAliasResolver{
+		nameToAlias: map[string]string{},
+		aliasToName: map[string]string{},
+	}
+	for _, f := range fields {
+		if f.Alias == "" {
+			continue
+		}
+		if _, ok := r.nameToAlias[f.Name]; !ok {
+			r.nameToAlias[f.Name] = f.Alias
+		}
+		if _, ok := r.aliasToName[f.Alias]; !ok {
+			r.aliasToName[f.Alias] = f.Name
+		}
+	}
+	return r
+}
+
+// NameToAlias returns the alias for a field's display name
+func (r *FieldAliasResolver) NameToAlias(name string) (string, bool) {
+	alias, ok := r.nameToAlias[name]
+	return alias, ok
+}
+
+// AliasToName returns the display name for a field's alias
+func (r *FieldAliasResolver) AliasToName(alias string) (string, bool) {
+	name, ok := r.aliasToName[alias]
+	return name, ok
+}
+
+// NameOrAlias returns the display name for a field's alias, or the alias itself if it's unknown.  It can be given
+// to RemapKeys to key a map by display name.
+func (r *FieldAliasResolver) NameOrAlias(alias string) string {
+	if name, ok := r.aliasToName[alias]; ok {
+		return name
+	}
+	return alias
+}
+
+// RemapKeys returns a copy of m with each key replaced by the result of keyFunc, e.g. SnakeCase or a
+// FieldAliasResolver's NameOrAlias, for use with maps such as those from GetEmployeeCustomFields or ParseReportCSV.
+// Keys keyFunc returns empty are left as they are, and where several keys map to the same key the value of the first
+// key in sorted order is kept.
+func RemapKeys(m map[string]string, keyFunc func(string) string) map[string]string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	remapped := make(map[string]string, len(m))
+	for _, k := range keys {
+		nk := keyFunc(k)
+		if nk == "" {
+			nk = k
+		}
+		if _, ok := remapped[nk]; !ok {
+			remapped[nk] = m[k]
+		}
+	}
+	return remapped
+}
+
+// SnakeCase converts a field alias or name to snake case, e.g. "workPhoneExtension" and "Work Phone Extension"
+// both become "work_phone_extension", and "photoURL" becomes "photo_url".
+func SnakeCase(s string) string {
+	runes := []rune(strings.TrimSpace(s))
+	var b strings.Builder
+	for i, r := range runes {
+		switch {
+		case r == ' ' || r == '-' || r == '_':
+			if b.Len() > 0 && !strings.HasSuffix(b.String(), "_") {
+				b.WriteRune('_')
+			}
+			continue
+		case unicode.IsUpper(r) && i > 0 && b.Len() > 0 && !strings.HasSuffix(b.String(), "_"):
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteRune('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return strings.TrimSuffix(b.String(), "_")
+}
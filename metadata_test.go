@@ -0,0 +1,133 @@
+package bamboohr
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMetaCache(t *testing.T) {
+	tests := []struct {
+		name     string
+		ttl      time.Duration
+		wait     time.Duration
+		refresh  bool
+		requests int32
+	}{
+		{name: "disabled", requests: 2},
+		{name: "cached", ttl: time.Hour, requests: 1},
+		{name: "expired", ttl: time.Millisecond, wait: 5 * time.Millisecond, requests: 2},
+		{name: "refreshed", ttl: time.Hour, refresh: true, requests: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int32
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasSuffix(r.URL.Path, "/meta/fields/") {
+					atomic.AddInt32(&requests, 1)
+					w.Write([]byte(`[{"id":"4001","name":"Shirt Size","type":"list","alias":"customShirtSize"}]`))
+					return
+				}
+				w.Write([]byte(`[{"alias":"jobInfo","fields":[{"id":4047,"name":"Job Title","alias":"jobTitle","type":"list"}]}]`))
+			})
+			c.MetaCacheTTL = tt.ttl
+			ctx := context.Background()
+
+			first, err := c.GetFields(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			first[0].Alias = "changed"
+			time.Sleep(tt.wait)
+			if tt.refresh {
+				if err := c.RefreshMetaCache(ctx); err != nil {
+					t.Fatal(err)
+				}
+			} else if _, err := c.GetFields(ctx); err != nil {
+				t.Fatal(err)
+			}
+			r, err := c.GetFieldAliasResolver(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if alias, ok := r.NameToAlias("Shirt Size"); !ok || alias != "customShirtSize" {
+				t.Errorf("NameToAlias = %q, %v; want cached fields unchanged by callers", alias, ok)
+			}
+			want := tt.requests
+			if tt.ttl == 0 {
+				want++ // GetFieldAliasResolver requests the fields again too
+			}
+			if got := atomic.LoadInt32(&requests); got != want {
+				t.Errorf("got %d requests, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestMetaCacheTables(t *testing.T) {
+	var requests int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte(`[{"alias":"jobInfo","fields":[{"id":4047,"name":"Job Title","alias":"jobTitle","type":"list"}]}]`))
+	})
+	c.MetaCacheTTL = time.Hour
+	ctx := context.Background()
+	first, err := c.GetTables(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first[0].Fields[0].Alias = "changed"
+	second, err := c.GetTables(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second[0].Fields[0].Alias != "jobTitle" {
+		t.Errorf("cached table field alias = %q, want jobTitle", second[0].Fields[0].Alias)
+	}
+	if requests != 1 {
+		t.Errorf("got %d requests, want 1", requests)
+	}
+}
+
+func TestFieldAliasResolver(t *testing.T) {
+	r := NewFieldAliasResolver([]FieldMeta{
+		{ID: "1", Name: "First Name", Alias: "firstName"},
+		{ID: "4001", Name: "Shirt Size", Alias: "customShirtSize"},
+		{ID: "4002", Name: "Badge Colour"},
+		{ID: "4003", Name: "Shirt Size", Alias: "customShirtSize2"},
+		{ID: "4004", Name: "T-Shirt Size", Alias: "customShirtSize"},
+	})
+	tests := []struct {
+		name, alias string
+		ok          bool
+	}{
+		{name: "First Name", alias: "firstName", ok: true},
+		{name: "Shirt Size", alias: "customShirtSize", ok: true},
+		{name: "Badge Colour"},
+		{name: "first name"},
+		{name: ""},
+		{name: "Unknown"},
+	}
+	for _, tt := range tests {
+		if alias, ok := r.NameToAlias(tt.name); alias != tt.alias || ok != tt.ok {
+			t.Errorf("NameToAlias(%q) = %q, %v, want %q, %v", tt.name, alias, ok, tt.alias, tt.ok)
+		}
+	}
+	for _, alias := range []string{"customShoeSize", "FirstName", "4002", ""} {
+		if name, ok := r.AliasToName(alias); ok || name != "" {
+			t.Errorf("AliasToName(%q) = %q, %v, want not found", alias, name, ok)
+		}
+		if got := r.NameOrAlias(alias); got != alias {
+			t.Errorf("NameOrAlias(%q) = %q, want it unchanged", alias, got)
+		}
+	}
+	if name, ok := r.AliasToName("customShirtSize"); !ok || name != "Shirt Size" {
+		t.Errorf("AliasToName(customShirtSize) = %q, %v, want the first name", name, ok)
+	}
+	if name, ok := r.AliasToName("customShirtSize2"); !ok || name != "Shirt Size" {
+		t.Errorf("AliasToName(customShirtSize2) = %q, %v, want Shirt Size", name, ok)
+	}
+}
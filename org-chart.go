@@ -0,0 +1,138 @@
+package bamboohr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// OrgNode is an employee in an org chart along with the employees reporting to them
+type OrgNode struct {
+	Employee Employee
+	Reports  []*OrgNode
+}
+
+// MarshalJSON encodes the node as the employee's fields with their reports nested under "reports".  A node without
+// an employee, such as the root joining several tops of the chart together, only has "reports".
+func (n *OrgNode) MarshalJSON() ([]byte, error) {
+	reports := n.Reports
+	if reports == nil {
+		reports = []*OrgNode{}
+	}
+	if n.Employee.ID == "" {
+		return json.Marshal(struct {
+			Reports []*OrgNode `json:"reports"`
+		}{reports})
+	}
+	type employee Employee // without the methods of Employee
+	return json.Marshal(struct {
+		employee
+		Reports []*OrgNode `json:"reports"`
+	}{employee(n.Employee), reports})
+}
+
+// BuildOrgChart returns the org chart from the directory, starting at the employee with the given ID.  If no ID is
+// given the chart starts at the top, the employee without a supervisor, or a node without an employee joining them
+// together if there are several.  Reports are in directory order.  An error is returned if supervisors loop.
+func (c *Client) BuildOrgChart(ctx context.Context, rootID string) (*OrgNode, error) {
+	directory, err := c.GetEmployeeDirectory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewDirectoryIndex(directory).OrgChart(rootID)
+}
+
+// OrgChart returns the org chart starting at the employee with the given ID, or the top of the chart if no ID is
+// given, in the same way as BuildOrgChart.
+func (ix *DirectoryIndex) OrgChart(rootID string) (*OrgNode, error) {
+	reports := map[string][]string{}
+	var tops []string
+	for _, id := range ix.ids {
+		if sid, ok := ix.SupervisorID(ix.byID[id]); ok && sid != id {
+			reports[sid] = append(reports[sid], id)
+		} else {
+			tops = append(tops, id)
+		}
+	}
+
+	seen := map[string]bool{}
+	var build func(id string) (*OrgNode, error)
+	build = func(id string) (*OrgNode, error) {
+		if seen[id] {
+			return nil, fmt.Errorf("org chart loops at employee %s", id)
+		}
+		seen[id] = true
+		node := &OrgNode{Employee: ix.byID[id]}
+		for _, rid := range reports[id] {
+			child, err := build(rid)
+			if err != nil {
+				return nil, err
+			}
+			node.Reports = append(node.Reports, child)
+		}
+		return node, nil
+	}
+
+	if rootID != "" {
+		if _, ok := ix.byID[rootID]; !ok {
+			return nil, ErrEmployeeNotFound
+		}
+		return build(rootID)
+	}
+	root := &OrgNode{}
+	for _, id := range tops {
+		node, err := build(id)
+		if err != nil {
+			return nil, err
+		}
+		root.Reports = append(root.Reports, node)
+	}
+	// Employees only reachable through a loop of supervisors are left out above
+	for _, id := range ix.ids {
+		if !seen[id] {
+			return nil, fmt.Errorf("org chart loops at employee %s", id)
+		}
+	}
+	if len(root.Reports) == 1 {
+		return root.Reports[0], nil
+	}
+	return root, nil
+}
+
+// OrgRow is an employee in a flattened org chart
+type OrgRow struct {
+	Employee Employee
+	// Depth below the top of the chart, starting at 0
+	Depth int
+	// ManagerID is the ID of the employee's manager in the chart, empty at the top
+	ManagerID string
+}
+
+// Flatten returns the employees in the chart as rows, each followed by the employees reporting to them, for showing
+// as an indented table.  Employees reporting to the same manager are ordered by DisplayName and then ID.  A node
+// without an employee, such as the root joining several tops of the chart together, isn't included.
+func (n *OrgNode) Flatten() []OrgRow {
+	var rows []OrgRow
+	var flatten func(node *OrgNode, depth int, managerID string)
+	flatten = func(node *OrgNode, depth int, managerID string) {
+		if node.Employee.ID != "" {
+			rows = append(rows, OrgRow{Employee: node.Employee, Depth: depth, ManagerID: managerID})
+			managerID = node.Employee.ID
+			depth++
+		}
+		reports := append([]*OrgNode(nil), node.Reports...)
+		sort.SliceStable(reports, func(i, j int) bool {
+			a, b := reports[i].Employee, reports[j].Employee
+			if a.DisplayName != b.DisplayName {
+				return a.DisplayName < b.DisplayName
+			}
+			return a.ID < b.ID
+		})
+		for _, r := range reports {
+			flatten(r, depth, managerID)
+		}
+	}
+	flatten(n, 0, "")
+	return rows
+}
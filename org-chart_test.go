@@ -0,0 +1,195 @@
+package bamboohr
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+// orgDirectory is a small hierarchy with a CEO, two managers and their reports, listed out of order
+const orgDirectory = `{"fields":[],"employees":[` +
+	`{"id":"4","displayName":"Di Engineer","supervisor":"Bo Manager"},` +
+	`{"id":"1","displayName":"Ada Chief"},` +
+	`{"id":"3","displayName":"Cy Manager","supervisor":"Ada Chief"},` +
+	`{"id":"2","displayName":"Bo Manager","supervisor":"Ada Chief"},` +
+	`{"id":"5","displayName":"Ed Analyst","supervisor":"Cy Manager"},` +
+	`{"id":"6","displayName":"Al Engineer","supervisor":"Bo Manager"}]}`
+
+func TestBuildOrgChart(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(orgDirectory))
+	})
+	ctx := context.Background()
+	chart, err := c.BuildOrgChart(ctx, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(chart)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		ID          string `json:"id"`
+		DisplayName string `json:"displayName"`
+		Reports     []struct {
+			ID      string `json:"id"`
+			Reports []struct {
+				ID      string        `json:"id"`
+				Reports []interface{} `json:"reports"`
+			} `json:"reports"`
+		} `json:"reports"`
+	}
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != "1" || got.DisplayName != "Ada Chief" || len(got.Reports) != 2 {
+		t.Fatalf("top of chart = %s", b)
+	}
+	// reports are in directory order
+	cy, bo := got.Reports[0], got.Reports[1]
+	if cy.ID != "3" || len(cy.Reports) != 1 || cy.Reports[0].ID != "5" {
+		t.Errorf("Cy's reports = %+v", cy)
+	}
+	if bo.ID != "2" || len(bo.Reports) != 2 || bo.Reports[0].ID != "4" || bo.Reports[1].ID != "6" {
+		t.Errorf("Bo's reports = %+v", bo)
+	}
+	if r := bo.Reports[0].Reports; r == nil || len(r) != 0 {
+		t.Errorf("employee without reports has %v, want an empty list", r)
+	}
+
+	sub, err := c.BuildOrgChart(ctx, "3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sub.Employee.ID != "3" || len(sub.Reports) != 1 || sub.Reports[0].Employee.ID != "5" {
+		t.Errorf("chart from 3 = %+v", sub)
+	}
+	if _, err := c.BuildOrgChart(ctx, "9"); err != ErrEmployeeNotFound {
+		t.Errorf("err = %v, want ErrEmployeeNotFound", err)
+	}
+}
+
+func TestOrgChartSeveralTops(t *testing.T) {
+	chart, err := NewDirectoryIndex([]Employee{
+		{ID: "1", DisplayName: "Ada Chief"},
+		{ID: "2", DisplayName: "Bo Founder"},
+		{ID: "3", DisplayName: "Cy Manager", SupervisorEID: "2"},
+	}).OrgChart("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chart.Employee.ID != "" || len(chart.Reports) != 2 {
+		t.Fatalf("root = %+v, want a node joining both tops", chart)
+	}
+	b, err := json.Marshal(chart)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var root map[string]interface{}
+	if err := json.Unmarshal(b, &root); err != nil {
+		t.Fatal(err)
+	}
+	if len(root) != 1 || root["reports"] == nil {
+		t.Errorf("root without an employee = %s, want only reports", b)
+	}
+}
+
+func TestOrgChartCycles(t *testing.T) {
+	tests := []struct {
+		name      string
+		directory []Employee
+		rootID    string
+	}{
+		{
+			name: "loop below the top",
+			directory: []Employee{
+				{ID: "1"},
+				{ID: "2", SupervisorEID: "3"},
+				{ID: "3", SupervisorEID: "2"},
+			},
+		},
+		{
+			name: "loop without a top",
+			directory: []Employee{
+				{ID: "1", SupervisorEID: "2"},
+				{ID: "2", SupervisorEID: "1"},
+			},
+		},
+		{
+			name: "loop from the root",
+			directory: []Employee{
+				{ID: "1", SupervisorEID: "2"},
+				{ID: "2", SupervisorEID: "1"},
+			},
+			rootID: "1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewDirectoryIndex(tt.directory).OrgChart(tt.rootID); err == nil {
+				t.Error("no error for a loop")
+			}
+		})
+	}
+	// An employee recorded as their own supervisor is at the top rather than a loop
+	chart, err := NewDirectoryIndex([]Employee{{ID: "1", SupervisorEID: "1"}}).OrgChart("")
+	if err != nil || chart.Employee.ID != "1" {
+		t.Errorf("self-supervised chart = %+v, %v", chart, err)
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	chart, err := NewDirectoryIndex([]Employee{
+		{ID: "4", DisplayName: "Di Engineer", SupervisorEID: "2"},
+		{ID: "1", DisplayName: "Ada Chief"},
+		{ID: "3", DisplayName: "Cy Manager", SupervisorEID: "1"},
+		{ID: "2", DisplayName: "Bo Manager", SupervisorEID: "1"},
+		{ID: "5", DisplayName: "Ed Analyst", SupervisorEID: "3"},
+		{ID: "7", DisplayName: "Al Engineer", SupervisorEID: "2"},
+		{ID: "6", DisplayName: "Al Engineer", SupervisorEID: "2"},
+		{ID: "8", DisplayName: "Fi Intern", SupervisorEID: "5"},
+	}).OrgChart("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		id, managerID string
+		depth         int
+	}{
+		{"1", "", 0},
+		{"2", "1", 1},
+		{"6", "2", 2}, // same name as 7, so ordered by ID
+		{"7", "2", 2},
+		{"4", "2", 2},
+		{"3", "1", 1},
+		{"5", "3", 2},
+		{"8", "5", 3},
+	}
+	rows := chart.Flatten()
+	if len(rows) != len(want) {
+		t.Fatalf("got %d rows, want %d", len(rows), len(want))
+	}
+	for i, w := range want {
+		if r := rows[i]; r.Employee.ID != w.id || r.ManagerID != w.managerID || r.Depth != w.depth {
+			t.Errorf("row %d = %s, manager %q, depth %d, want %s, manager %q, depth %d",
+				i, r.Employee.ID, r.ManagerID, r.Depth, w.id, w.managerID, w.depth)
+		}
+	}
+
+	// The node joining several tops isn't a row, so the tops are at depth 0
+	joined := &OrgNode{Reports: []*OrgNode{
+		{Employee: Employee{ID: "2", DisplayName: "Bo Founder"}},
+		{Employee: Employee{ID: "1", DisplayName: "Ada Chief"}, Reports: []*OrgNode{
+			{Employee: Employee{ID: "3", DisplayName: "Cy Manager"}},
+		}},
+	}}
+	rows = joined.Flatten()
+	if len(rows) != 3 || rows[0].Employee.ID != "1" || rows[0].Depth != 0 || rows[1].Employee.ID != "3" ||
+		rows[1].Depth != 1 || rows[1].ManagerID != "1" || rows[2].Employee.ID != "2" || rows[2].Depth != 0 {
+		t.Errorf("got %+v", rows)
+	}
+	if chart.Reports[0].Employee.ID != "3" || chart.Reports[1].Employee.ID != "2" {
+		t.Error("Flatten reordered the chart")
+	}
+}
@@ -0,0 +1,22 @@
+package bamboohr
+
+import "strings"
+
+// ProfileCompleteness returns the fraction of the required fields the employee has set, from 0 to 1, along with
+// the fields that are missing in the order given.  Empty or blank strings and nil pointers count as missing, as do
+// fields Employee doesn't have, such as custom fields.  With no required fields the score is 1.
+func ProfileCompleteness(e Employee, required []EmployeeField) (score float64, missing []EmployeeField) {
+	if len(required) == 0 {
+		return 1, nil
+	}
+	set := map[EmployeeField]bool{}
+	for _, fv := range e.fieldValues() {
+		set[fv.field] = fv.set && strings.TrimSpace(fv.value) != ""
+	}
+	for _, f := range required {
+		if !set[f] {
+			missing = append(missing, f)
+		}
+	}
+	return float64(len(required)-len(missing)) / float64(len(required)), missing
+}
@@ -0,0 +1,58 @@
+package bamboohr
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProfileCompleteness(t *testing.T) {
+	complete := Employee{
+		FirstName:      "Jo",
+		LastName:       "Bloggs",
+		WorkEmail:      "jo@example.com",
+		MaritalStatus:  MaritalStatusSingle,
+		PhotoUploaded:  boolPtr(false),
+		CanUploadPhoto: intPtr(0),
+	}
+	required := []EmployeeField{FirstName, LastName, WorkEmail, MaritalStatusField, PhotoUploaded, CanUploadPhoto}
+	tests := []struct {
+		name     string
+		e        Employee
+		required []EmployeeField
+		score    float64
+		missing  []EmployeeField
+	}{
+		{name: "nothing required", e: Employee{}, score: 1},
+		{name: "complete, with zero values in pointers", e: complete, required: required, score: 1},
+		{name: "empty employee", e: Employee{}, required: required, score: 0, missing: required},
+		{
+			name:     "nil pointers missing",
+			e:        Employee{FirstName: "Jo", LastName: "Bloggs", WorkEmail: "jo@example.com", MaritalStatus: MaritalStatusSingle},
+			required: required,
+			score:    4.0 / 6,
+			missing:  []EmployeeField{PhotoUploaded, CanUploadPhoto},
+		},
+		{
+			name:     "blank strings missing",
+			e:        Employee{FirstName: " ", LastName: "Bloggs", WorkEmail: "\t"},
+			required: []EmployeeField{WorkEmail, FirstName, LastName, JobTitle},
+			score:    0.25,
+			missing:  []EmployeeField{WorkEmail, FirstName, JobTitle},
+		},
+		{
+			name:     "renamed and unknown fields",
+			e:        Employee{Zip: "84042", SupervisorEID: "1"},
+			required: []EmployeeField{Zipcode, SupervisorEID, "customShirtSize"},
+			score:    2.0 / 3,
+			missing:  []EmployeeField{"customShirtSize"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, missing := ProfileCompleteness(tt.e, tt.required)
+			if score != tt.score || !reflect.DeepEqual(missing, tt.missing) {
+				t.Errorf("got %v, %v, want %v, %v", score, missing, tt.score, tt.missing)
+			}
+		})
+	}
+}
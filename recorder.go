@@ -0,0 +1,150 @@
+package bamboohr
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// RecorderMode controls whether a Recorder makes real requests or replays recorded ones
+type RecorderMode int
+
+// Recorder modes
+const (
+	// RecorderModeRecord makes real requests and saves them to the cassette, replacing anything already there
+	RecorderModeRecord RecorderMode = iota
+	// RecorderModeReplay answers requests from the cassette without making any real requests
+	RecorderModeReplay
+)
+
+// Recorder is an http.RoundTripper that records requests and responses to a file, known as a cassette, so tests can
+// replay them later without access to a Bamboo HR account.  Use it as the Transport of the http.Client given to New.
+//
+// Requests are matched on their method, path and query, in the order they were recorded.  The Authorization header
+// is never written to the cassette, but response bodies are saved as they are, so check cassettes before committing.
+type Recorder struct {
+	// Path to the cassette file, e.g. "testdata/directory.json"
+	Path string
+	Mode RecorderMode
+	// Transport used to make real requests when recording, http.DefaultTransport if nil
+	Transport http.RoundTripper
+
+	mu           sync.Mutex
+	loaded       bool
+	interactions []*interaction
+}
+
+// interaction is a recorded request and its response
+type interaction struct {
+	Method     string      `json:"method"`
+	Path       string      `json:"path"`
+	Query      string      `json:"query"`
+	Header     http.Header `json:"header"`
+	StatusCode int         `json:"statusCode"`
+	Response   http.Header `json:"responseHeader"`
+	Body       string      `json:"body"`
+	used       bool
+}
+
+// RoundTrip records or replays a request depending on the Recorder's Mode
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.Mode == RecorderModeReplay {
+		return r.replay(req)
+	}
+	return r.record(req)
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	res, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	// Keep cassettes readable by saving bodies uncompressed
+	if res.Header.Get("Content-Encoding") == "gzip" {
+		zr, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		if body, err = ioutil.ReadAll(zr); err != nil {
+			return nil, err
+		}
+		res.Header.Del("Content-Encoding")
+		res.Header.Del("Content-Length")
+	}
+
+	header := req.Header.Clone()
+	header.Del("Authorization")
+	r.interactions = append(r.interactions, &interaction{
+		Method:     req.Method,
+		Path:       req.URL.Path,
+		Query:      req.URL.RawQuery,
+		Header:     header,
+		StatusCode: res.StatusCode,
+		Response:   res.Header,
+		Body:       string(body),
+	})
+	if err := r.save(); err != nil {
+		return nil, err
+	}
+	return newRecordedResponse(req, res.StatusCode, res.Header, body), nil
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	if !r.loaded {
+		b, err := ioutil.ReadFile(r.Path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(b, &r.interactions); err != nil {
+			return nil, err
+		}
+		r.loaded = true
+	}
+	for _, i := range r.interactions {
+		if i.used || i.Method != req.Method || i.Path != req.URL.Path || i.Query != req.URL.RawQuery {
+			continue
+		}
+		i.used = true
+		return newRecordedResponse(req, i.StatusCode, i.Response, []byte(i.Body)), nil
+	}
+	return nil, fmt.Errorf("no recorded response for %s %s", req.Method, req.URL)
+}
+
+// save writes all of the interactions recorded so far to the cassette
+func (r *Recorder) save() error {
+	b, err := json.MarshalIndent(r.interactions, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.Path, b, os.FileMode(0644))
+}
+
+func newRecordedResponse(req *http.Request, statusCode int, header http.Header, body []byte) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		StatusCode:    statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header.Clone(),
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
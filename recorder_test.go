@@ -0,0 +1,106 @@
+package bamboohr
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRecorderRoundTrip(t *testing.T) {
+	const directory = `{"fields":[],"employees":[{"id":"1","displayName":"Jo Bloggs"}]}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			t.Error("request sent without Authorization")
+		}
+		switch r.URL.Path {
+		case "/employees/directory":
+			w.Header().Set("Content-Encoding", "gzip")
+			w.Write(gzipped(t, directory))
+		case "/employees/1":
+			w.Write([]byte(`{"id":"1","firstName":"Jo"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	cassette := filepath.Join(t.TempDir(), "cassette.json")
+	ctx := context.Background()
+
+	newClient := func(mode RecorderMode) *Client {
+		c, err := New("secret-key", "company", &http.Client{Transport: &Recorder{Path: cassette, Mode: mode}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.BaseURL = server.URL
+		return c
+	}
+
+	recording := newClient(RecorderModeRecord)
+	recordedDirectory, err := recording.GetEmployeeDirectory(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recordedEmployee, err := recording.GetEmployee(ctx, "1", FirstName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ioutil.ReadFile(cassette)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(strings.ToLower(string(b)), "authorization") || strings.Contains(string(b), recording.Auth) {
+		t.Errorf("cassette contains the Authorization header:\n%s", b)
+	}
+	var interactions []struct {
+		Path     string      `json:"path"`
+		Query    string      `json:"query"`
+		Response http.Header `json:"responseHeader"`
+		Body     string      `json:"body"`
+	}
+	if err := json.Unmarshal(b, &interactions); err != nil {
+		t.Fatal(err)
+	}
+	if len(interactions) != 2 {
+		t.Fatalf("recorded %d interactions, want 2", len(interactions))
+	}
+	if interactions[0].Body != directory || interactions[0].Response.Get("Content-Encoding") != "" {
+		t.Errorf("directory stored as %q with headers %v, want it decompressed", interactions[0].Body, interactions[0].Response)
+	}
+	if interactions[1].Path != "/employees/1" || interactions[1].Query == "" {
+		t.Errorf("employee request recorded as %q?%q", interactions[1].Path, interactions[1].Query)
+	}
+
+	server.Close()
+	replaying := newClient(RecorderModeReplay)
+	replayedDirectory, err := replaying.GetEmployeeDirectory(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(replayedDirectory, recordedDirectory) {
+		t.Errorf("replayed directory %+v, want %+v", replayedDirectory, recordedDirectory)
+	}
+	replayedEmployee, err := replaying.GetEmployee(ctx, "1", FirstName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(replayedEmployee, recordedEmployee) {
+		t.Errorf("replayed employee %+v, want %+v", replayedEmployee, recordedEmployee)
+	}
+
+	if _, err := replaying.GetEmployeeDirectory(ctx); err == nil {
+		t.Error("recorded response replayed twice")
+	}
+	if _, err := replaying.GetEmployee(ctx, "1", LastName); err == nil {
+		t.Error("no error for a request with a different query")
+	}
+	if _, err := replaying.GetEmployee(ctx, "2", FirstName); err == nil {
+		t.Error("no error for a request with a different path")
+	}
+}
@@ -0,0 +1,130 @@
+package bamboohr
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// requestCustomReport runs a custom report of all employees, including inactive ones, with the given fields
+// and decodes the JSON response, which holds the rows under "employees", into v.
+func (c *Client) requestCustomReport(ctx context.Context, fields []string, v interface{}) error {
+	body, err := json.Marshal(map[string][]string{"fields": fields})
+	if err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s/reports/custom", c.BaseURL)
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	q := req.URL.Query()
+	q.Add("format", "JSON")
+	req.URL.RawQuery = q.Encode()
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(ctx)
+	return c.makeRequest(req, v)
+}
+
+// ParseReportCSV reads a report in CSV format and returns each record as a map keyed by the header row.
+// An empty report returns no records.
+func ParseReportCSV(r io.Reader) ([]map[string]string, error) {
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	// Reports may start with a byte order mark which would otherwise end up in the first key
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	var records []map[string]string
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		record := make(map[string]string, len(header))
+		for i, key := range header {
+			record[key] = row[i]
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// GetFieldForEmployees returns the value of a field for each of the given employees, keyed by ID, using a single
+// custom report of every employee rather than a request per employee.  The report includes all employees however few
+// are asked for, so for a handful of IDs GetEmployeeField may be cheaper.  If the report is refused or doesn't include
+// the field, the field is requested for each employee concurrently instead.  IDs that don't match an employee are
+// left out.
+func (c *Client) GetFieldForEmployees(ctx context.Context, ids []string, field EmployeeField) (map[string]string, error) {
+	values := make(map[string]string, len(ids))
+	if len(ids) == 0 {
+		return values, nil
+	}
+	wanted := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+	report := struct {
+		Title     string                   `json:"title"`
+		Fields    []DirectoryField         `json:"fields"`
+		Employees []map[string]interface{} `json:"employees"`
+	}{}
+	err := c.requestCustomReport(ctx, []string{"id", string(field)}, &report)
+	if err != nil && !isStatus(err, http.StatusBadRequest) && !isStatus(err, http.StatusForbidden) &&
+		!isStatus(err, http.StatusNotFound) {
+		return nil, err
+	}
+	if err == nil && reportHasField(report.Employees, string(field)) {
+		for _, row := range report.Employees {
+			id := rawFieldString(row["id"])
+			if wanted[id] {
+				values[id] = rawFieldString(lookupField(row, string(field)))
+			}
+		}
+		return values, nil
+	}
+
+	var mu sync.Mutex
+	errs := forEachConcurrently(ctx, ids, func(id string) error {
+		value, err := c.GetEmployeeField(ctx, id, string(field))
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		values[id] = value
+		mu.Unlock()
+		return nil
+	})
+	for _, id := range ids {
+		if err := errs[id]; err != nil && err != ErrEmployeeNotFound {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// reportHasField reports whether the rows of a report include the field, or true if there are no rows
+func reportHasField(rows []map[string]interface{}, field string) bool {
+	if len(rows) == 0 {
+		return true
+	}
+	for k := range rows[0] {
+		if strings.EqualFold(k, field) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package bamboohr
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestGetFieldForEmployees(t *testing.T) {
+	tests := []struct {
+		name   string
+		report func(w http.ResponseWriter)
+		// employeeRequests made when falling back to requesting the field for each employee
+		employeeRequests int
+	}{
+		{
+			name: "report",
+			report: func(w http.ResponseWriter) {
+				w.Write([]byte(`{"title":"Report","fields":[` +
+					`{"id":"id","type":"int","name":"ID"},{"id":"status","type":"status","name":"Status"}],` +
+					`"employees":[{"id":"1","status":"Active"},{"id":"2","status":"Inactive"},{"id":"3","status":"Active"}]}`))
+			},
+		},
+		{
+			name: "report refused",
+			report: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusForbidden)
+			},
+			employeeRequests: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var employeeRequests int
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/reports/custom":
+					tt.report(w)
+				case "/employees/1":
+					employeeRequests++
+					w.Write([]byte(`{"id":"1","status":"Active"}`))
+				case "/employees/2":
+					employeeRequests++
+					w.Write([]byte(`{"id":"2","status":"Inactive"}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			})
+			c.StrictJSON = true
+			values, err := c.GetFieldForEmployees(context.Background(), []string{"1", "2", "9"}, Status)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := map[string]string{"1": "Active", "2": "Inactive"}; !reflect.DeepEqual(values, want) {
+				t.Errorf("got %v, want %v", values, want)
+			}
+			if employeeRequests != tt.employeeRequests {
+				t.Errorf("made %d employee requests, want %d", employeeRequests, tt.employeeRequests)
+			}
+		})
+	}
+}
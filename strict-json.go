@@ -0,0 +1,81 @@
+package bamboohr
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// checkUnknownFields returns an error naming the first field in the decoded JSON that t doesn't have, for StrictJSON.
+// Fields are matched against the JSON names of struct fields, ignoring case as encoding/json does.  Decoders don't
+// apply DisallowUnknownFields to types with their own UnmarshalJSON, such as Employee, so the check is made here
+// against the Go types instead.  Types that don't decode from a JSON object, e.g. FieldID, aren't looked into.
+func checkUnknownFields(data interface{}, t reflect.Type, path string) error {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch data := data.(type) {
+	case map[string]interface{}:
+		switch t.Kind() {
+		case reflect.Map:
+			for k, v := range data {
+				if err := checkUnknownFields(v, t.Elem(), path+"."+k); err != nil {
+					return err
+				}
+			}
+		case reflect.Struct:
+			fields := jsonFields(t)
+			for k, v := range data {
+				ft, ok := fields[strings.ToLower(k)]
+				if !ok {
+					return fmt.Errorf("json: unknown field %q", strings.TrimPrefix(path+"."+k, "."))
+				}
+				if err := checkUnknownFields(v, ft, path+"."+k); err != nil {
+					return err
+				}
+			}
+		}
+	case []interface{}:
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for i, v := range data {
+				if err := checkUnknownFields(v, t.Elem(), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// jsonFields returns the types of a struct's fields keyed by their lower cased JSON names, including the fields of
+// embedded structs.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name := strings.Split(sf.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		ft := sf.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if sf.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			for k, v := range jsonFields(ft) {
+				if _, ok := fields[k]; !ok {
+					fields[k] = v
+				}
+			}
+			continue
+		}
+		if sf.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		fields[strings.ToLower(name)] = sf.Type
+	}
+	return fields
+}
@@ -0,0 +1,46 @@
+package bamboohr
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// GetEmployeeTimeZone returns the time zone of a specific employee.  If the Client's TimeZoneField is set and the
+// employee has a valid IANA time zone in it, that's used.  Otherwise their location is looked up in the Client's
+// LocationTimeZones, or used directly if it's already an IANA time zone such as "Europe/London".  Bamboo locations
+// are free text, so no other mapping is attempted and an ErrUnknownTimeZone is returned instead.
+func (c *Client) GetEmployeeTimeZone(ctx context.Context, employeeID string) (*time.Location, error) {
+	aliases := []string{"location"}
+	if c.TimeZoneField != "" {
+		aliases = append(aliases, c.TimeZoneField)
+	}
+	fields, err := c.GetEmployeeCustomFields(ctx, employeeID, aliases)
+	if isStatus(err, http.StatusNotFound) {
+		return nil, ErrEmployeeNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if c.TimeZoneField != "" {
+		if tz := strings.TrimSpace(fields[c.TimeZoneField]); tz != "" {
+			if loc, err := time.LoadLocation(tz); err == nil {
+				return loc, nil
+			}
+		}
+	}
+	location := strings.TrimSpace(fields["location"])
+	for l, tz := range c.LocationTimeZones {
+		if normalizeName(l) == normalizeName(location) {
+			return time.LoadLocation(tz)
+		}
+	}
+	// Only names with a slash, since LoadLocation also accepts "", "UTC" and "Local"
+	if strings.Contains(location, "/") {
+		if loc, err := time.LoadLocation(location); err == nil {
+			return loc, nil
+		}
+	}
+	return nil, &ErrUnknownTimeZone{Location: location}
+}
@@ -0,0 +1,68 @@
+package bamboohr
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetEmployeeTimeZone(t *testing.T) {
+	employees := map[string]string{
+		"/employees/1": `{"id":"1","location":"London (LDN)"}`,
+		"/employees/2": `{"id":"2","location":"  new   york "}`,
+		"/employees/3": `{"id":"3","location":"Asia/Tokyo"}`,
+		"/employees/4": `{"id":"4","location":"Remote"}`,
+		"/employees/5": `{"id":"5","location":null}`,
+		"/employees/6": `{"id":"6","location":"Remote","customTimeZone":"Australia/Sydney"}`,
+		"/employees/7": `{"id":"7","location":"London (LDN)","customTimeZone":"Not/AZone"}`,
+		"/employees/8": `{"id":"8","location":"UTC"}`,
+	}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, ok := employees[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if fields := r.URL.Query().Get("fields"); !strings.Contains(fields, "location") {
+			t.Errorf("requested fields %q, want location", fields)
+		}
+		w.Write([]byte(body))
+	})
+	c.LocationTimeZones = map[string]string{
+		"London (LDN)": "Europe/London",
+		"New York":     "America/New_York",
+	}
+	c.TimeZoneField = "customTimeZone"
+	tests := []struct {
+		id      string
+		want    string
+		unknown string
+	}{
+		{id: "1", want: "Europe/London"},
+		{id: "2", want: "America/New_York"},
+		{id: "3", want: "Asia/Tokyo"},
+		{id: "4", unknown: "Remote"},
+		{id: "5", unknown: ""},
+		{id: "6", want: "Australia/Sydney"},
+		{id: "7", want: "Europe/London"},
+		{id: "8", unknown: "UTC"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			loc, err := c.GetEmployeeTimeZone(context.Background(), tt.id)
+			if tt.want != "" {
+				if err != nil || loc.String() != tt.want {
+					t.Errorf("got %v, %v, want %s", loc, err, tt.want)
+				}
+				return
+			}
+			if e, ok := err.(*ErrUnknownTimeZone); !ok || e.Location != tt.unknown {
+				t.Errorf("got %v, %v, want ErrUnknownTimeZone for %q", loc, err, tt.unknown)
+			}
+		})
+	}
+	if _, err := c.GetEmployeeTimeZone(context.Background(), "9"); err != ErrEmployeeNotFound {
+		t.Errorf("err = %v, want ErrEmployeeNotFound", err)
+	}
+}